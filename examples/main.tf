@@ -15,6 +15,13 @@ provider "ceph" {
   # These are coming from env vars
   # access_key = ""
   # secret_key = ""
+
+  # Use short-lived credentials from RGW STS
+  # assume_role {
+  #   role_arn     = "arn:aws:iam:::role/ci"
+  #   session_name = "terraform"
+  #   duration     = "1h"
+  # }
 }
 
 resource "ceph_rgw_user" "test" {
@@ -25,6 +32,22 @@ output "resource_ceph_rgw_user_test" {
   sensitive = true
 }
 
+resource "ceph_rgw_account" "team" {
+  name        = "team"
+  email       = "team@example.com"
+  max_buckets = 100
+  quota = {
+    max_size    = 1099511627776
+    max_objects = 10000000
+  }
+}
+
+resource "ceph_rgw_user" "team_root" {
+  id           = "team-root"
+  account_id   = resource.ceph_rgw_account.team.id
+  account_root = true
+}
+
 resource "ceph_rgw_bucket" "hot" {
   name               = "tf-test-hot"
   versioning_enabled = true
@@ -51,3 +74,116 @@ output "resource_ceph_rgw_bucket_cold" {
 }
 
 
+resource "ceph_rgw_role" "ci" {
+  name = "ci"
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = { AWS = ["arn:aws:iam:::user/tf-test"] }
+      Action    = ["sts:AssumeRole"]
+    }]
+  })
+  max_session_duration = 7200
+}
+
+resource "ceph_rgw_role_policy" "ci_buckets" {
+  role = resource.ceph_rgw_role.ci.name
+  name = "buckets"
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect   = "Allow"
+      Action   = ["s3:*"]
+      Resource = ["arn:aws:s3:::tf-test-hot", "arn:aws:s3:::tf-test-hot/*"]
+    }]
+  })
+}
+
+resource "ceph_rgw_openid_connect_provider" "kubernetes" {
+  url             = "https://kubernetes.default.svc"
+  client_id_list  = ["sts.amazonaws.com"]
+  thumbprint_list = ["9e99a48a9960b14926bb7f3b02e22da2b0ab7280"]
+}
+
+resource "ceph_rgw_role" "workload" {
+  name = "workload"
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = { Federated = [resource.ceph_rgw_openid_connect_provider.kubernetes.arn] }
+      Action    = ["sts:AssumeRoleWithWebIdentity"]
+      Condition = {
+        StringEquals = { "kubernetes.default.svc:sub" = "system:serviceaccount:default:workload" }
+      }
+    }]
+  })
+}
+
+resource "ceph_rgw_iam_user" "deploy" {
+  name = "deploy"
+  path = "/ci/"
+}
+
+resource "ceph_rgw_iam_group" "deployers" {
+  name = "deployers"
+}
+
+resource "ceph_rgw_iam_group_membership" "deployers" {
+  group = resource.ceph_rgw_iam_group.deployers.name
+  users = [resource.ceph_rgw_iam_user.deploy.name]
+}
+
+resource "ceph_rgw_iam_user_policy" "deploy_buckets" {
+  user = resource.ceph_rgw_iam_user.deploy.name
+  name = "buckets"
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect   = "Allow"
+      Action   = ["s3:GetObject", "s3:PutObject"]
+      Resource = ["arn:aws:s3:::tf-test-hot/*"]
+    }]
+  })
+}
+
+resource "ceph_rgw_iam_access_key" "deploy" {
+  user = resource.ceph_rgw_iam_user.deploy.name
+}
+output "resource_ceph_rgw_iam_access_key_deploy" {
+  value     = resource.ceph_rgw_iam_access_key.deploy
+  sensitive = true
+}
+
+resource "ceph_rgw_user_ratelimit" "test" {
+  user_id        = resource.ceph_rgw_user.test.id
+  max_read_ops   = 1000
+  max_write_ops  = 200
+  max_read_bytes = 1073741824
+}
+
+resource "ceph_rgw_bucket_ratelimit" "hot" {
+  bucket          = resource.ceph_rgw_bucket.hot.name
+  max_write_ops   = 100
+  max_write_bytes = 104857600
+}
+
+resource "ceph_rgw_global_ratelimit" "anonymous" {
+  scope         = "anon"
+  max_read_ops  = 100
+  max_write_ops = 10
+}
+
+resource "ceph_rgw_object" "index" {
+  bucket       = resource.ceph_rgw_bucket.hot.name
+  key          = "index.html"
+  content      = "<html><body>Hello from RGW</body></html>"
+  content_type = "text/html"
+  metadata = {
+    owner = "tf-test"
+  }
+}
+output "resource_ceph_rgw_object_index" {
+  value = resource.ceph_rgw_object.index
+}
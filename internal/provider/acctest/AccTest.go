@@ -0,0 +1,47 @@
+// Package acctest runs the provider against a fake RGW, so that acceptance
+// tests need neither a Ceph cluster nor credentials.
+package acctest
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider"
+	"terraform-provider-ceph/internal/provider/fakergw"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// ProtoV6ProviderFactories serves the provider in-process under the name
+// "ceph".
+var ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"ceph": providerserver.NewProtocol6WithError(provider.New("test")()),
+}
+
+// NewServer starts a fake RGW that is closed at the end of the test.
+func NewServer(t *testing.T) *fakergw.Server {
+	t.Helper()
+
+	server := fakergw.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ProviderConfig configures the provider with the admin keys of server.
+func ProviderConfig(server *fakergw.Server) string {
+	return ProviderConfigWithKeys(server, server.AccessKey, server.SecretKey)
+}
+
+// ProviderConfigWithKeys configures the provider to call server with the
+// given keys.
+func ProviderConfigWithKeys(server *fakergw.Server, accessKey string, secretKey string) string {
+	return fmt.Sprintf(`
+provider "ceph" {
+  endpoint    = %q
+  access_key  = %q
+  secret_key  = %q
+  max_retries = 0
+}
+`, server.URL, accessKey, secretKey)
+}
@@ -3,12 +3,12 @@ package datasources
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	lib "terraform-provider-ceph/internal/provider/lib"
 	model "terraform-provider-ceph/internal/provider/models"
 
-	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/ceph/go-ceph/rgw/admin"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -56,21 +56,26 @@ func (d *RgwBucketDataSource) Configure(_ context.Context, req datasource.Config
 }
 
 func (d *RgwBucketDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data model.RgwBucket
+	var config model.RgwBucketDataSourceModel
 
 	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	var data = model.RgwBucket{
+		Name:          config.Name,
+		PlacementRule: config.PlacementRule,
+	}
+
 	var name = data.Name.ValueString()
 
 	bucket, err := d.clientLibs.Rgw.GetBucketInfo(ctx, admin.Bucket{Bucket: name})
 
 	if err != nil {
-		// Check if the error is a "NoSuchBucket" error
-		if strings.HasPrefix(err.Error(), "NoSuchBucket") {
+		// Check if the bucket is gone
+		if lib.IsNotFound(err) {
 			tflog.Debug(ctx, "Bucket "+name+" not found, removing from state")
 			resp.State.RemoveResource(ctx)
 			return
@@ -80,26 +85,31 @@ func (d *RgwBucketDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	var requestedPlacementRule = data.PlacementRule
+
 	data = model.ToRgwBucket(bucket)
+	model.NormalizeRgwBucketPlacement(&data, requestedPlacementRule, func() string {
+		return d.clientLibs.GetDefaultPlacement(ctx)
+	})
 
-	versioning, err := d.clientLibs.S3.GetBucketVersioning(&s3.GetBucketVersioningInput{
+	versioning, err := d.clientLibs.S3.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 		Bucket: data.Name.ValueStringPointer(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get bucket versioning", err.Error())
 		return
 	}
-	if versioning.Status != nil && *versioning.Status == s3.BucketVersioningStatusEnabled {
+	if versioning.Status == s3types.BucketVersioningStatusEnabled {
 		data.VersioningEnabled = types.BoolValue(true)
 	} else {
 		data.VersioningEnabled = types.BoolValue(false)
 	}
 
 	// Now get bucket policy and set it in the state
-	policyJson, err := d.clientLibs.S3.GetBucketPolicy(&s3.GetBucketPolicyInput{
+	policyJson, err := d.clientLibs.S3.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
 		Bucket: &name,
 	})
-	if err != nil && !strings.HasPrefix(err.Error(), "NoSuchBucketPolicy") {
+	if err != nil && !lib.HasErrorCode(err, lib.ErrCodeNoSuchBucketPolicy) {
 		resp.Diagnostics.AddError("Failed to get bucket policy", err.Error())
 		return
 	}
@@ -115,10 +125,10 @@ func (d *RgwBucketDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	// Now get bucket lifecycle policy and set it in the state
-	lifecyclePolicy, err := d.clientLibs.S3.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+	lifecyclePolicy, err := d.clientLibs.S3.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
 		Bucket: &name,
 	})
-	if err != nil && !strings.HasPrefix(err.Error(), "NoSuchLifecycleConfiguration") {
+	if err != nil && !lib.HasErrorCode(err, lib.ErrCodeNoSuchLifecycleConfiguration) {
 		resp.Diagnostics.AddError("Failed to get bucket lifecycle policy", err.Error())
 		return
 	}
@@ -126,8 +136,25 @@ func (d *RgwBucketDataSource) Read(ctx context.Context, req datasource.ReadReque
 		model.ReadS3LifecyclePolicyRulesIntoBucket(&data, lifecyclePolicy.Rules)
 	}
 
+	// Reading the bucket instance metadata needs the metadata=read cap, which
+	// nothing else in this data source does, so the reshard status is only
+	// reported when it can be read
+	reshardStatus := types.StringNull()
+	status, err := d.clientLibs.Admin.GetBucketReshardStatus(ctx, name, bucket.ID)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Failed to get bucket reshard status",
+			"The reshard_status attribute is left null. Reading it requires the metadata=read capability.\n\n"+
+				"Error: "+err.Error(),
+		)
+	} else {
+		reshardStatus = types.StringValue(status)
+	}
+
+	config = model.ToRgwBucketDataSourceModel(data, model.ToRgwBucketStats(bucket), reshardStatus)
+
 	// Set state
-	diags := resp.State.Set(ctx, &data)
+	diags := resp.State.Set(ctx, &config)
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
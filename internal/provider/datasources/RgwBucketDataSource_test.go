@@ -0,0 +1,97 @@
+package datasources_test
+
+import (
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwBucketDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_user" "reader" {
+  id   = "reader"
+  name = "Reader"
+}
+
+resource "ceph_rgw_bucket" "test" {
+  name               = "photos"
+  versioning_enabled = true
+
+  permission {
+    user_id     = ceph_rgw_user.reader.id
+    permissions = ["s3:GetObject"]
+  }
+
+  lifecycle_delete {
+    id            = "expire-tmp"
+    object_prefix = "tmp/"
+    after_days    = 7
+  }
+}
+
+resource "ceph_rgw_object" "test" {
+  bucket  = ceph_rgw_bucket.test.name
+  key     = "cat.txt"
+  content = "meow!"
+}
+
+data "ceph_rgw_bucket" "test" {
+  name       = ceph_rgw_bucket.test.name
+  depends_on = [ceph_rgw_object.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "owner", "admin"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "placement_rule", "default-placement"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "versioning_enabled", "true"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "num_shards", "11"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "reshard_status", "not-resharding"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "usage.num_objects", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "usage.size", "5"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "quota.enabled", "false"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "permission.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "permission.0.user_id", "reader"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "lifecycle_delete.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "lifecycle_delete.0.id", "expire-tmp"),
+					resource.TestCheckResourceAttrSet("data.ceph_rgw_bucket.test", "id"),
+					resource.TestCheckResourceAttrSet("data.ceph_rgw_bucket.test", "creation_time"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwBucketDataSource_withoutMetadataCaps(t *testing.T) {
+	server := acctest.NewServer(t)
+	accessKey, secretKey := server.AddUser("operator", "buckets=*;users=read")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfigWithKeys(server, accessKey, secretKey) + `
+resource "ceph_rgw_bucket" "test" {
+  name = "logs"
+}
+
+data "ceph_rgw_bucket" "test" {
+  name = ceph_rgw_bucket.test.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "owner", "operator"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_bucket.test", "num_shards", "11"),
+					resource.TestCheckNoResourceAttr("data.ceph_rgw_bucket.test", "reshard_status"),
+				),
+			},
+		},
+	})
+}
@@ -28,8 +28,8 @@ type RgwBucketsDataSource struct {
 }
 
 type RgwBucketsDataSourceModel struct {
-	Name    types.String      `tfsdk:"name"`
-	Buckets []model.RgwBucket `tfsdk:"buckets"`
+	Name    types.String           `tfsdk:"name"`
+	Buckets []model.RgwBucketStats `tfsdk:"buckets"`
 }
 
 func (d *RgwBucketsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -45,7 +45,17 @@ func (d *RgwBucketsDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 			"buckets": schema.ListNestedAttribute{ // response
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: model.GetRgwBucketDatasourceSchema().Attributes,
+					Attributes: model.GetRgwBucketStatsDatasourceAttributes(map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"placement_rule": schema.StringAttribute{
+							Computed: true,
+						},
+						"storage_class": schema.StringAttribute{
+							Computed: true,
+						},
+					}),
 				},
 			},
 		},
@@ -89,7 +99,7 @@ func (d *RgwBucketsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	data.Buckets = []model.RgwBucket{}
+	data.Buckets = []model.RgwBucketStats{}
 
 	for _, bucketName := range bucketNames {
 		bucket, err := d.clientLibs.Rgw.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
@@ -103,7 +113,7 @@ func (d *RgwBucketsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 			continue
 		}
 
-		data.Buckets = append(data.Buckets, model.ToRgwBucket(bucket))
+		data.Buckets = append(data.Buckets, model.ToRgwBucketStats(bucket))
 	}
 
 	// Set state
@@ -0,0 +1,43 @@
+package datasources_test
+
+import (
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwBucketsDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_bucket" "test" {
+  count = 3
+  name  = ["logs-2024", "logs-2025", "photos"][count.index]
+}
+
+data "ceph_rgw_buckets" "all" {
+  depends_on = [ceph_rgw_bucket.test]
+}
+
+data "ceph_rgw_buckets" "logs" {
+  name       = "logs-"
+  depends_on = [ceph_rgw_bucket.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_buckets.all", "buckets.#", "3"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_buckets.logs", "buckets.#", "2"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_buckets.logs", "buckets.0.name", "logs-2024"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_buckets.logs", "buckets.0.placement_rule", "default-placement"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_buckets.logs", "buckets.1.name", "logs-2025"),
+				),
+			},
+		},
+	})
+}
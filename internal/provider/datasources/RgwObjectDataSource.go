@@ -0,0 +1,206 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &RgwObjectDataSource{}
+	_ datasource.DataSourceWithConfigure = &RgwObjectDataSource{}
+)
+
+// textContentTypes lists the non text/* content types whose body is returned.
+var textContentTypes = []string{
+	"application/json",
+	"application/xml",
+	"application/javascript",
+	"application/x-yaml",
+	"application/yaml",
+	"application/x-sh",
+	"application/toml",
+}
+
+func NewRgwObjectDataSource() datasource.DataSource {
+	return &RgwObjectDataSource{}
+}
+
+type RgwObjectDataSource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+type RgwObjectDataSourceModel struct {
+	Bucket        types.String `tfsdk:"bucket"`
+	Key           types.String `tfsdk:"key"`
+	VersionId     types.String `tfsdk:"version_id"`
+	Body          types.String `tfsdk:"body"`
+	ContentType   types.String `tfsdk:"content_type"`
+	ContentLength types.Int64  `tfsdk:"content_length"`
+	Etag          types.String `tfsdk:"etag"`
+	LastModified  types.String `tfsdk:"last_modified"`
+	StorageClass  types.String `tfsdk:"storage_class"`
+	Metadata      types.Map    `tfsdk:"metadata"`
+}
+
+func (d *RgwObjectDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_object"
+}
+
+func (d *RgwObjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{ // request
+				Required: true,
+			},
+			"key": schema.StringAttribute{ // request
+				Required: true,
+			},
+			"version_id": schema.StringAttribute{ // request
+				Optional: true,
+				Computed: true,
+			},
+			"body": schema.StringAttribute{ // response
+				Computed: true,
+			},
+			"content_type": schema.StringAttribute{
+				Computed: true,
+			},
+			"content_length": schema.Int64Attribute{
+				Computed: true,
+			},
+			"etag": schema.StringAttribute{
+				Computed: true,
+			},
+			"last_modified": schema.StringAttribute{
+				Computed: true,
+			},
+			"storage_class": schema.StringAttribute{
+				Computed: true,
+			},
+			"metadata": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *RgwObjectDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.clientLibs = clientLibs
+}
+
+func (d *RgwObjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RgwObjectDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var key = data.Key.ValueString()
+
+	input := s3.GetObjectInput{
+		Bucket: data.Bucket.ValueStringPointer(),
+		Key:    data.Key.ValueStringPointer(),
+	}
+	if !data.VersionId.IsNull() {
+		input.VersionId = data.VersionId.ValueStringPointer()
+	}
+
+	object, err := d.clientLibs.S3.GetObject(ctx, &input)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get object "+key, err.Error())
+		return
+	}
+	defer object.Body.Close()
+
+	data.VersionId = types.StringPointerValue(object.VersionId)
+	data.ContentType = types.StringPointerValue(object.ContentType)
+	data.ContentLength = types.Int64PointerValue(object.ContentLength)
+	data.Etag = types.StringValue(lib.NormalizeS3Etag(object.ETag))
+
+	if object.StorageClass != "" {
+		data.StorageClass = types.StringValue(string(object.StorageClass))
+	} else {
+		data.StorageClass = types.StringValue(string(s3types.StorageClassStandard))
+	}
+
+	if object.LastModified != nil {
+		data.LastModified = types.StringValue(object.LastModified.Format(time.RFC3339))
+	} else {
+		data.LastModified = types.StringNull()
+	}
+
+	metadata := map[string]string{}
+	for name, value := range object.Metadata {
+		metadata[strings.ToLower(name)] = value
+	}
+	data.Metadata, _ = types.MapValueFrom(ctx, types.StringType, metadata)
+
+	// Only text bodies are returned, binary content cannot be represented
+	// in a string attribute.
+	if isTextContentType(data.ContentType.ValueString()) {
+		body, err := io.ReadAll(object.Body)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read object "+key, err.Error())
+			return
+		}
+		data.Body = types.StringValue(string(body))
+	} else {
+		data.Body = types.StringNull()
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func isTextContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+
+	for _, textContentType := range textContentTypes {
+		if mediaType == textContentType {
+			return true
+		}
+	}
+
+	return false
+}
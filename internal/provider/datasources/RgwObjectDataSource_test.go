@@ -0,0 +1,52 @@
+package datasources_test
+
+import (
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwObjectDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_bucket" "test" {
+  name = "documents"
+}
+
+resource "ceph_rgw_object" "test" {
+  bucket       = ceph_rgw_bucket.test.name
+  key          = "notes/todo.txt"
+  content      = "buy milk"
+  content_type = "text/plain"
+
+  metadata = {
+    author = "alice"
+  }
+}
+
+data "ceph_rgw_object" "test" {
+  bucket     = ceph_rgw_object.test.bucket
+  key        = ceph_rgw_object.test.key
+  depends_on = [ceph_rgw_object.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_object.test", "body", "buy milk"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_object.test", "content_type", "text/plain"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_object.test", "content_length", "8"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_object.test", "storage_class", "STANDARD"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_object.test", "metadata.author", "alice"),
+					resource.TestCheckResourceAttrPair("data.ceph_rgw_object.test", "etag", "ceph_rgw_object.test", "etag"),
+					resource.TestCheckResourceAttrSet("data.ceph_rgw_object.test", "last_modified"),
+				),
+			},
+		},
+	})
+}
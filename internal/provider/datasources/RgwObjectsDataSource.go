@@ -0,0 +1,208 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &RgwObjectsDataSource{}
+	_ datasource.DataSourceWithConfigure = &RgwObjectsDataSource{}
+)
+
+const defaultRgwObjectsMaxKeys = 1000
+
+func NewRgwObjectsDataSource() datasource.DataSource {
+	return &RgwObjectsDataSource{}
+}
+
+type RgwObjectsDataSource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+type RgwObjectsDataSourceModel struct {
+	Bucket         types.String       `tfsdk:"bucket"`
+	Prefix         types.String       `tfsdk:"prefix"`
+	Delimiter      types.String       `tfsdk:"delimiter"`
+	StartAfter     types.String       `tfsdk:"start_after"`
+	MaxKeys        types.Int64        `tfsdk:"max_keys"`
+	Keys           []types.String     `tfsdk:"keys"`
+	CommonPrefixes []types.String     `tfsdk:"common_prefixes"`
+	Objects        []RgwObjectSummary `tfsdk:"objects"`
+}
+
+type RgwObjectSummary struct {
+	Key          types.String `tfsdk:"key"`
+	Size         types.Int64  `tfsdk:"size"`
+	Etag         types.String `tfsdk:"etag"`
+	LastModified types.String `tfsdk:"last_modified"`
+	StorageClass types.String `tfsdk:"storage_class"`
+}
+
+func (d *RgwObjectsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_objects"
+}
+
+func (d *RgwObjectsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{ // request
+				Required: true,
+			},
+			"prefix": schema.StringAttribute{ // request
+				Optional: true,
+			},
+			"delimiter": schema.StringAttribute{ // request
+				Optional: true,
+			},
+			"start_after": schema.StringAttribute{ // request
+				Optional: true,
+			},
+			"max_keys": schema.Int64Attribute{ // request
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"keys": schema.ListAttribute{ // response
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"common_prefixes": schema.ListAttribute{ // response
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"objects": schema.ListNestedAttribute{ // response
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed: true,
+						},
+						"size": schema.Int64Attribute{
+							Computed: true,
+						},
+						"etag": schema.StringAttribute{
+							Computed: true,
+						},
+						"last_modified": schema.StringAttribute{
+							Computed: true,
+						},
+						"storage_class": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *RgwObjectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.clientLibs = clientLibs
+}
+
+func (d *RgwObjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RgwObjectsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var bucket = data.Bucket.ValueString()
+	var maxKeys = int64(defaultRgwObjectsMaxKeys)
+	if !data.MaxKeys.IsNull() {
+		maxKeys = data.MaxKeys.ValueInt64()
+	}
+
+	input := s3.ListObjectsV2Input{
+		Bucket:  data.Bucket.ValueStringPointer(),
+		MaxKeys: aws.Int32(int32(min(maxKeys, defaultRgwObjectsMaxKeys))),
+	}
+	if !data.Prefix.IsNull() {
+		input.Prefix = data.Prefix.ValueStringPointer()
+	}
+	if !data.Delimiter.IsNull() {
+		input.Delimiter = data.Delimiter.ValueStringPointer()
+	}
+	if !data.StartAfter.IsNull() {
+		input.StartAfter = data.StartAfter.ValueStringPointer()
+	}
+
+	data.Keys = []types.String{}
+	data.CommonPrefixes = []types.String{}
+	data.Objects = []RgwObjectSummary{}
+
+	var count int64
+	paginator := s3.NewListObjectsV2Paginator(d.clientLibs.S3, &input)
+	for paginator.HasMorePages() && count < maxKeys {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list objects in bucket "+bucket, err.Error())
+			return
+		}
+
+		for _, commonPrefix := range page.CommonPrefixes {
+			if count >= maxKeys {
+				break
+			}
+			data.CommonPrefixes = append(data.CommonPrefixes, types.StringPointerValue(commonPrefix.Prefix))
+			count++
+		}
+
+		for _, object := range page.Contents {
+			if count >= maxKeys {
+				break
+			}
+
+			summary := RgwObjectSummary{
+				Key:          types.StringPointerValue(object.Key),
+				Size:         types.Int64PointerValue(object.Size),
+				Etag:         types.StringValue(lib.NormalizeS3Etag(object.ETag)),
+				StorageClass: types.StringValue(string(object.StorageClass)),
+				LastModified: types.StringNull(),
+			}
+			if object.LastModified != nil {
+				summary.LastModified = types.StringValue(object.LastModified.Format(time.RFC3339))
+			}
+
+			data.Keys = append(data.Keys, summary.Key)
+			data.Objects = append(data.Objects, summary)
+			count++
+		}
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,64 @@
+package datasources_test
+
+import (
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwObjectsDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_bucket" "test" {
+  name = "archive"
+}
+
+resource "ceph_rgw_object" "test" {
+  count   = 4
+  bucket  = ceph_rgw_bucket.test.name
+  key     = ["2024/jan.log", "2024/feb.log", "2025/jan.log", "index.html"][count.index]
+  content = ["2024/jan.log", "2024/feb.log", "2025/jan.log", "index.html"][count.index]
+}
+
+data "ceph_rgw_objects" "all" {
+  bucket     = ceph_rgw_bucket.test.name
+  depends_on = [ceph_rgw_object.test]
+}
+
+data "ceph_rgw_objects" "top" {
+  bucket     = ceph_rgw_bucket.test.name
+  delimiter  = "/"
+  depends_on = [ceph_rgw_object.test]
+}
+
+data "ceph_rgw_objects" "year" {
+  bucket     = ceph_rgw_bucket.test.name
+  prefix     = "2024/"
+  max_keys   = 1
+  depends_on = [ceph_rgw_object.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.all", "keys.#", "4"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.all", "keys.0", "2024/feb.log"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.all", "objects.0.size", "12"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.all", "objects.0.storage_class", "STANDARD"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.top", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.top", "keys.0", "index.html"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.top", "common_prefixes.#", "2"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.top", "common_prefixes.0", "2024/"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.top", "common_prefixes.1", "2025/"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.year", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_objects.year", "keys.0", "2024/feb.log"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,135 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &RgwRealmDataSource{}
+	_ datasource.DataSourceWithConfigure = &RgwRealmDataSource{}
+)
+
+func NewRgwRealmDataSource() datasource.DataSource {
+	return &RgwRealmDataSource{}
+}
+
+type RgwRealmDataSource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+type RgwRealmDataSourceModel struct {
+	Id                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Epoch             types.Int64    `tfsdk:"epoch"`
+	CurrentPeriod     types.String   `tfsdk:"current_period"`
+	PeriodEpoch       types.Int64    `tfsdk:"period_epoch"`
+	MasterZoneGroupId types.String   `tfsdk:"master_zonegroup_id"`
+	MasterZoneId      types.String   `tfsdk:"master_zone_id"`
+	ZoneGroups        []types.String `tfsdk:"zonegroups"`
+}
+
+func (d *RgwRealmDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_realm"
+}
+
+func (d *RgwRealmDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{ // request
+				Optional: true,
+				Computed: true,
+			},
+			"name": schema.StringAttribute{ // request
+				Optional: true,
+				Computed: true,
+			},
+			"epoch": schema.Int64Attribute{ // response
+				Computed: true,
+			},
+			"current_period": schema.StringAttribute{
+				Computed: true,
+			},
+			"period_epoch": schema.Int64Attribute{
+				Computed: true,
+			},
+			"master_zonegroup_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"master_zone_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"zonegroups": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *RgwRealmDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.clientLibs = clientLibs
+}
+
+func (d *RgwRealmDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RgwRealmDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	realm, err := d.clientLibs.Admin.GetRealm(ctx, data.Id.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get realm", err.Error())
+		return
+	}
+
+	period, err := d.clientLibs.Admin.GetPeriod(ctx, realm.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get current period of realm "+realm.Name, err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(realm.Id)
+	data.Name = types.StringValue(realm.Name)
+	data.Epoch = types.Int64Value(realm.Epoch)
+	data.CurrentPeriod = types.StringValue(realm.CurrentPeriod)
+	data.PeriodEpoch = types.Int64Value(period.Epoch)
+	data.MasterZoneGroupId = types.StringValue(period.MasterZoneGroup)
+	data.MasterZoneId = types.StringValue(period.MasterZone)
+
+	data.ZoneGroups = []types.String{}
+	for _, zoneGroup := range period.PeriodMap.ZoneGroups {
+		data.ZoneGroups = append(data.ZoneGroups, types.StringValue(zoneGroup.Name))
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
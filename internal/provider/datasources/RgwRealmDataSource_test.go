@@ -0,0 +1,57 @@
+package datasources_test
+
+import (
+	"regexp"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwRealmDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+data "ceph_rgw_realm" "default" {}
+
+data "ceph_rgw_realm" "named" {
+  name = "fake"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_realm.default", "name", "fake"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_realm.default", "epoch", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_realm.default", "zonegroups.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_realm.default", "zonegroups.0", "default"),
+					resource.TestCheckResourceAttrSet("data.ceph_rgw_realm.default", "current_period"),
+					resource.TestCheckResourceAttrSet("data.ceph_rgw_realm.default", "master_zonegroup_id"),
+					resource.TestCheckResourceAttrSet("data.ceph_rgw_realm.default", "master_zone_id"),
+					resource.TestCheckResourceAttrPair("data.ceph_rgw_realm.named", "id", "data.ceph_rgw_realm.default", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwRealmDataSource_missingRealm(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+data "ceph_rgw_realm" "test" {
+  name = "elsewhere"
+}
+`,
+				ExpectError: regexp.MustCompile(`NoSuchKey`),
+			},
+		},
+	})
+}
@@ -0,0 +1,342 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rgwUsageTimeLayout is the time format expected by the usage admin API.
+const rgwUsageTimeLayout = "2006-01-02 15:04:05"
+
+var (
+	_ datasource.DataSource              = &RgwUsageDataSource{}
+	_ datasource.DataSourceWithConfigure = &RgwUsageDataSource{}
+)
+
+func NewRgwUsageDataSource() datasource.DataSource {
+	return &RgwUsageDataSource{}
+}
+
+type RgwUsageDataSource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+type RgwUsageDataSourceModel struct {
+	UserId     types.String            `tfsdk:"user_id"`
+	Start      types.String            `tfsdk:"start"`
+	End        types.String            `tfsdk:"end"`
+	Total      *RgwUsageCountersModel  `tfsdk:"total"`
+	Categories []RgwUsageCategoryModel `tfsdk:"categories"`
+	Users      []RgwUsageUserModel     `tfsdk:"users"`
+	Buckets    []RgwUsageBucketModel   `tfsdk:"buckets"`
+}
+
+type RgwUsageCountersModel struct {
+	BytesSent     types.Int64 `tfsdk:"bytes_sent"`
+	BytesReceived types.Int64 `tfsdk:"bytes_received"`
+	Ops           types.Int64 `tfsdk:"ops"`
+	SuccessfulOps types.Int64 `tfsdk:"successful_ops"`
+}
+
+type RgwUsageCategoryModel struct {
+	Category      types.String `tfsdk:"category"`
+	BytesSent     types.Int64  `tfsdk:"bytes_sent"`
+	BytesReceived types.Int64  `tfsdk:"bytes_received"`
+	Ops           types.Int64  `tfsdk:"ops"`
+	SuccessfulOps types.Int64  `tfsdk:"successful_ops"`
+}
+
+type RgwUsageUserModel struct {
+	UserId        types.String            `tfsdk:"user_id"`
+	BytesSent     types.Int64             `tfsdk:"bytes_sent"`
+	BytesReceived types.Int64             `tfsdk:"bytes_received"`
+	Ops           types.Int64             `tfsdk:"ops"`
+	SuccessfulOps types.Int64             `tfsdk:"successful_ops"`
+	Categories    []RgwUsageCategoryModel `tfsdk:"categories"`
+}
+
+type RgwUsageBucketModel struct {
+	Bucket        types.String            `tfsdk:"bucket"`
+	Owner         types.String            `tfsdk:"owner"`
+	BytesSent     types.Int64             `tfsdk:"bytes_sent"`
+	BytesReceived types.Int64             `tfsdk:"bytes_received"`
+	Ops           types.Int64             `tfsdk:"ops"`
+	SuccessfulOps types.Int64             `tfsdk:"successful_ops"`
+	Categories    []RgwUsageCategoryModel `tfsdk:"categories"`
+}
+
+// rgwUsageCounters accumulates the counters of a user, a bucket or a category.
+type rgwUsageCounters struct {
+	bytesSent     uint64
+	bytesReceived uint64
+	ops           uint64
+	successfulOps uint64
+}
+
+func (c *rgwUsageCounters) add(bytesSent, bytesReceived, ops, successfulOps uint64) {
+	c.bytesSent += bytesSent
+	c.bytesReceived += bytesReceived
+	c.ops += ops
+	c.successfulOps += successfulOps
+}
+
+func (c rgwUsageCounters) toModel() RgwUsageCountersModel {
+	return RgwUsageCountersModel{
+		BytesSent:     types.Int64Value(int64(c.bytesSent)),
+		BytesReceived: types.Int64Value(int64(c.bytesReceived)),
+		Ops:           types.Int64Value(int64(c.ops)),
+		SuccessfulOps: types.Int64Value(int64(c.successfulOps)),
+	}
+}
+
+// rgwUsageCategories accumulates counters per category, in the order the
+// categories are first seen.
+type rgwUsageCategories struct {
+	names    []string
+	counters map[string]*rgwUsageCounters
+}
+
+func (c *rgwUsageCategories) get(category string) *rgwUsageCounters {
+	if c.counters == nil {
+		c.counters = map[string]*rgwUsageCounters{}
+	}
+	if _, found := c.counters[category]; !found {
+		c.names = append(c.names, category)
+		c.counters[category] = &rgwUsageCounters{}
+	}
+	return c.counters[category]
+}
+
+func (c rgwUsageCategories) toModel() []RgwUsageCategoryModel {
+	categories := []RgwUsageCategoryModel{}
+	for _, name := range c.names {
+		counters := c.counters[name].toModel()
+		categories = append(categories, RgwUsageCategoryModel{
+			Category:      types.StringValue(name),
+			BytesSent:     counters.BytesSent,
+			BytesReceived: counters.BytesReceived,
+			Ops:           counters.Ops,
+			SuccessfulOps: counters.SuccessfulOps,
+		})
+	}
+	return categories
+}
+
+func rgwUsageCounterAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	for _, name := range []string{"bytes_sent", "bytes_received", "ops", "successful_ops"} {
+		attributes[name] = schema.Int64Attribute{
+			Computed: true,
+		}
+	}
+	return attributes
+}
+
+func rgwUsageCategoriesAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: rgwUsageCounterAttributes(map[string]schema.Attribute{
+				"category": schema.StringAttribute{
+					Computed: true,
+				},
+			}),
+		},
+	}
+}
+
+func (d *RgwUsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_usage"
+}
+
+func (d *RgwUsageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{ // request
+				Optional: true,
+			},
+			"start": schema.StringAttribute{
+				Optional: true,
+			},
+			"end": schema.StringAttribute{
+				Optional: true,
+			},
+			"total": schema.SingleNestedAttribute{ // response
+				Computed:   true,
+				Attributes: rgwUsageCounterAttributes(map[string]schema.Attribute{}),
+			},
+			"categories": rgwUsageCategoriesAttribute(),
+			"users": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: rgwUsageCounterAttributes(map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"categories": rgwUsageCategoriesAttribute(),
+					}),
+				},
+			},
+			"buckets": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: rgwUsageCounterAttributes(map[string]schema.Attribute{
+						"bucket": schema.StringAttribute{
+							Computed: true,
+						},
+						"owner": schema.StringAttribute{
+							Computed: true,
+						},
+						"categories": rgwUsageCategoriesAttribute(),
+					}),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *RgwUsageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.clientLibs = clientLibs
+}
+
+func (d *RgwUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RgwUsageDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Times are given as RFC 3339 and sent to RGW in UTC
+	var bounds = map[string]string{}
+	for name, value := range map[string]types.String{"start": data.Start, "end": data.End} {
+		if value.IsNull() {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid "+name+" time", "Expected an RFC 3339 time such as 2024-01-31T00:00:00Z: "+err.Error())
+			continue
+		}
+		bounds[name] = parsed.UTC().Format(rgwUsageTimeLayout)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	showEntries := true
+	showSummary := true
+
+	usage, err := d.clientLibs.Rgw.GetUsage(ctx, admin.Usage{
+		UserID:      data.UserId.ValueString(),
+		Start:       bounds["start"],
+		End:         bounds["end"],
+		ShowEntries: &showEntries,
+		ShowSummary: &showSummary,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get RGW usage", err.Error())
+		return
+	}
+
+	var total rgwUsageCounters
+	var categories rgwUsageCategories
+
+	data.Users = []RgwUsageUserModel{}
+	for _, summary := range usage.Summary {
+		var userCategories rgwUsageCategories
+		for _, category := range summary.Categories {
+			userCategories.get(category.Category).add(category.BytesSent, category.BytesReceived, category.Ops, category.SuccessfulOps)
+			categories.get(category.Category).add(category.BytesSent, category.BytesReceived, category.Ops, category.SuccessfulOps)
+		}
+		total.add(summary.Total.BytesSent, summary.Total.BytesReceived, summary.Total.Ops, summary.Total.SuccessfulOps)
+
+		counters := rgwUsageCounters{
+			bytesSent:     summary.Total.BytesSent,
+			bytesReceived: summary.Total.BytesReceived,
+			ops:           summary.Total.Ops,
+			successfulOps: summary.Total.SuccessfulOps,
+		}.toModel()
+		data.Users = append(data.Users, RgwUsageUserModel{
+			UserId:        types.StringValue(summary.User),
+			BytesSent:     counters.BytesSent,
+			BytesReceived: counters.BytesReceived,
+			Ops:           counters.Ops,
+			SuccessfulOps: counters.SuccessfulOps,
+			Categories:    userCategories.toModel(),
+		})
+	}
+
+	totalModel := total.toModel()
+	data.Total = &totalModel
+	data.Categories = categories.toModel()
+
+	// Entries are logged per bucket and per hour, sum them up per bucket
+	type bucketUsage struct {
+		bucket     string
+		owner      string
+		counters   rgwUsageCounters
+		categories rgwUsageCategories
+	}
+	var buckets []*bucketUsage
+	var bucketsByKey = map[string]*bucketUsage{}
+
+	for _, entry := range usage.Entries {
+		for _, bucket := range entry.Buckets {
+			key := bucket.Owner + "/" + bucket.Bucket
+			if _, found := bucketsByKey[key]; !found {
+				bucketsByKey[key] = &bucketUsage{bucket: bucket.Bucket, owner: bucket.Owner}
+				buckets = append(buckets, bucketsByKey[key])
+			}
+			summary := bucketsByKey[key]
+			for _, category := range bucket.Categories {
+				summary.counters.add(category.BytesSent, category.BytesReceived, category.Ops, category.SuccessfulOps)
+				summary.categories.get(category.Category).add(category.BytesSent, category.BytesReceived, category.Ops, category.SuccessfulOps)
+			}
+		}
+	}
+
+	data.Buckets = []RgwUsageBucketModel{}
+	for _, bucket := range buckets {
+		counters := bucket.counters.toModel()
+		data.Buckets = append(data.Buckets, RgwUsageBucketModel{
+			Bucket:        types.StringValue(bucket.bucket),
+			Owner:         types.StringValue(bucket.owner),
+			BytesSent:     counters.BytesSent,
+			BytesReceived: counters.BytesReceived,
+			Ops:           counters.Ops,
+			SuccessfulOps: counters.SuccessfulOps,
+			Categories:    bucket.categories.toModel(),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
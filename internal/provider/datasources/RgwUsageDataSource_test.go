@@ -0,0 +1,72 @@
+package datasources_test
+
+import (
+	"regexp"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwUsageDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_bucket" "test" {
+  name = "metered"
+}
+
+resource "ceph_rgw_object" "test" {
+  bucket  = ceph_rgw_bucket.test.name
+  key     = "data.bin"
+  content = "0123456789"
+}
+
+data "ceph_rgw_usage" "test" {
+  user_id    = "admin"
+  start      = "2000-01-01T00:00:00Z"
+  depends_on = [ceph_rgw_object.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_usage.test", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_usage.test", "users.0.user_id", "admin"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ceph_rgw_usage.test", "buckets.*", map[string]string{
+						"bucket": "metered",
+						"owner":  "admin",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ceph_rgw_usage.test", "categories.*", map[string]string{
+						"category":       "put_obj",
+						"bytes_received": "10",
+						"ops":            "1",
+						"successful_ops": "1",
+					}),
+					resource.TestCheckResourceAttrSet("data.ceph_rgw_usage.test", "total.ops"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwUsageDataSource_invalidTime(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+data "ceph_rgw_usage" "test" {
+  start = "yesterday"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid start time`),
+			},
+		},
+	})
+}
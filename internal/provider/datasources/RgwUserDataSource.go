@@ -52,7 +52,7 @@ func (d *RgwUserDataSource) Configure(_ context.Context, req datasource.Configur
 }
 
 func (d *RgwUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data model.RgwUser
+	var data model.RgwUserDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -73,7 +73,13 @@ func (d *RgwUserDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	data = model.ToRgwUser(user)
+	account, err := d.clientLibs.Admin.GetUserAccount(ctx, uid)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get account of user "+uid, err.Error())
+		return
+	}
+
+	data = model.ToRgwUserDataSourceModel(model.ToRgwUser(user, account))
 
 	// Set state
 	diags := resp.State.Set(ctx, &data)
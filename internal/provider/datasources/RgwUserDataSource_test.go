@@ -0,0 +1,58 @@
+package datasources_test
+
+import (
+	"regexp"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwUserDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_user" "test" {
+  id          = "alice"
+  name        = "Alice"
+  max_buckets = 5
+}
+
+data "ceph_rgw_user" "test" {
+  id = ceph_rgw_user.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_user.test", "name", "Alice"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_user.test", "max_buckets", "5"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_user.test", "account_root", "false"),
+					resource.TestCheckResourceAttrPair("data.ceph_rgw_user.test", "access_key", "ceph_rgw_user.test", "access_key"),
+					resource.TestCheckResourceAttrPair("data.ceph_rgw_user.test", "secret_key", "ceph_rgw_user.test", "secret_key"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwUserDataSource_missingUser(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+data "ceph_rgw_user" "test" {
+  id = "nobody"
+}
+`,
+				ExpectError: regexp.MustCompile(`NoSuchUser`),
+			},
+		},
+	})
+}
@@ -0,0 +1,230 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &RgwZoneDataSource{}
+	_ datasource.DataSourceWithConfigure = &RgwZoneDataSource{}
+)
+
+func NewRgwZoneDataSource() datasource.DataSource {
+	return &RgwZoneDataSource{}
+}
+
+type RgwZoneDataSource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+type RgwZoneDataSourceModel struct {
+	Name           types.String                `tfsdk:"name"`
+	Id             types.String                `tfsdk:"id"`
+	ZoneGroup      types.String                `tfsdk:"zonegroup"`
+	ZoneGroupId    types.String                `tfsdk:"zonegroup_id"`
+	RealmId        types.String                `tfsdk:"realm_id"`
+	IsMaster       types.Bool                  `tfsdk:"is_master"`
+	TierType       types.String                `tfsdk:"tier_type"`
+	Endpoints      []types.String              `tfsdk:"endpoints"`
+	PlacementPools []RgwZonePlacementPoolModel `tfsdk:"placement_pools"`
+}
+
+type RgwZonePlacementPoolModel struct {
+	Name           types.String               `tfsdk:"name"`
+	IndexPool      types.String               `tfsdk:"index_pool"`
+	DataExtraPool  types.String               `tfsdk:"data_extra_pool"`
+	StorageClasses []RgwZoneStorageClassModel `tfsdk:"storage_classes"`
+}
+
+type RgwZoneStorageClassModel struct {
+	Name            types.String `tfsdk:"name"`
+	DataPool        types.String `tfsdk:"data_pool"`
+	CompressionType types.String `tfsdk:"compression_type"`
+}
+
+func (d *RgwZoneDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_zone"
+}
+
+func (d *RgwZoneDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{ // request
+				Optional: true,
+				Computed: true,
+			},
+			"id": schema.StringAttribute{ // response
+				Computed: true,
+			},
+			"zonegroup": schema.StringAttribute{
+				Computed: true,
+			},
+			"zonegroup_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"realm_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"is_master": schema.BoolAttribute{
+				Computed: true,
+			},
+			"tier_type": schema.StringAttribute{
+				Computed: true,
+			},
+			"endpoints": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"placement_pools": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"index_pool": schema.StringAttribute{
+							Computed: true,
+						},
+						"data_extra_pool": schema.StringAttribute{
+							Computed: true,
+						},
+						"storage_classes": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed: true,
+									},
+									"data_pool": schema.StringAttribute{
+										Computed: true,
+									},
+									"compression_type": schema.StringAttribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *RgwZoneDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.clientLibs = clientLibs
+}
+
+func (d *RgwZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RgwZoneDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneGroups, err := d.clientLibs.Admin.GetZoneGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get zonegroups", err.Error())
+		return
+	}
+
+	// The pools of a zone can only be read from the gateways serving it
+	localZone, err := d.clientLibs.Admin.GetZone(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get zone", err.Error())
+		return
+	}
+
+	var zoneId = localZone.Id
+	if !data.Name.IsNull() && data.Name.ValueString() != localZone.Name {
+		zoneId = ""
+		for _, zoneGroup := range zoneGroups.ZoneGroups {
+			for _, zone := range zoneGroup.Zones {
+				if zone.Name == data.Name.ValueString() {
+					zoneId = zone.Id
+				}
+			}
+		}
+		if zoneId == "" {
+			resp.Diagnostics.AddError("Zone not found", "Zone "+data.Name.ValueString()+" does not exist in the current period")
+			return
+		}
+	}
+
+	zoneGroup, found := zoneGroups.FindZoneGroupOfZone(zoneId)
+	if !found {
+		resp.Diagnostics.AddError("Zonegroup not found", "No zonegroup contains zone "+zoneId)
+		return
+	}
+	zone, _ := zoneGroup.FindZone(zoneId)
+
+	data.Name = types.StringValue(zone.Name)
+	data.Id = types.StringValue(zone.Id)
+	data.ZoneGroup = types.StringValue(zoneGroup.Name)
+	data.ZoneGroupId = types.StringValue(zoneGroup.Id)
+	data.RealmId = types.StringValue(zoneGroup.RealmId)
+	data.IsMaster = types.BoolValue(zoneGroup.MasterZone == zone.Id)
+	data.TierType = types.StringValue(zone.TierType)
+	data.Endpoints = toStringValues(zone.Endpoints)
+
+	data.PlacementPools = []RgwZonePlacementPoolModel{}
+	if zoneId == localZone.Id {
+		for _, placementPool := range localZone.PlacementPools {
+			pool := RgwZonePlacementPoolModel{
+				Name:           types.StringValue(placementPool.Key),
+				IndexPool:      types.StringValue(placementPool.Val.IndexPool),
+				DataExtraPool:  types.StringValue(placementPool.Val.DataExtraPool),
+				StorageClasses: []RgwZoneStorageClassModel{},
+			}
+
+			var storageClasses []string
+			for name := range placementPool.Val.StorageClasses {
+				storageClasses = append(storageClasses, name)
+			}
+			sort.Strings(storageClasses)
+
+			for _, name := range storageClasses {
+				storageClass := placementPool.Val.StorageClasses[name]
+				pool.StorageClasses = append(pool.StorageClasses, RgwZoneStorageClassModel{
+					Name:            types.StringValue(name),
+					DataPool:        types.StringValue(storageClass.DataPool),
+					CompressionType: types.StringValue(storageClass.CompressionType),
+				})
+			}
+
+			data.PlacementPools = append(data.PlacementPools, pool)
+		}
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
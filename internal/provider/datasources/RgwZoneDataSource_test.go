@@ -0,0 +1,35 @@
+package datasources_test
+
+import (
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwZoneDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+data "ceph_rgw_zone" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "name", "default"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "zonegroup", "default"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "is_master", "true"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "endpoints.0", server.URL),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "placement_pools.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "placement_pools.0.name", "default-placement"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "placement_pools.0.index_pool", "default.rgw.buckets.index"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "placement_pools.0.storage_classes.0.name", "STANDARD"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "placement_pools.0.storage_classes.0.data_pool", "default.rgw.buckets.data"),
+				),
+			},
+		},
+	})
+}
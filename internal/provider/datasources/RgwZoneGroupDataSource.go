@@ -0,0 +1,228 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &RgwZoneGroupDataSource{}
+	_ datasource.DataSourceWithConfigure = &RgwZoneGroupDataSource{}
+)
+
+func NewRgwZoneGroupDataSource() datasource.DataSource {
+	return &RgwZoneGroupDataSource{}
+}
+
+type RgwZoneGroupDataSource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+type RgwZoneGroupDataSourceModel struct {
+	Name             types.String              `tfsdk:"name"`
+	Id               types.String              `tfsdk:"id"`
+	ApiName          types.String              `tfsdk:"api_name"`
+	IsMaster         types.Bool                `tfsdk:"is_master"`
+	RealmId          types.String              `tfsdk:"realm_id"`
+	MasterZoneId     types.String              `tfsdk:"master_zone_id"`
+	DefaultPlacement types.String              `tfsdk:"default_placement"`
+	Endpoints        []types.String            `tfsdk:"endpoints"`
+	Zones            []RgwZoneGroupZoneModel   `tfsdk:"zones"`
+	PlacementTargets []RgwPlacementTargetModel `tfsdk:"placement_targets"`
+}
+
+type RgwZoneGroupZoneModel struct {
+	Id        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	Endpoints []types.String `tfsdk:"endpoints"`
+	TierType  types.String   `tfsdk:"tier_type"`
+}
+
+type RgwPlacementTargetModel struct {
+	Name           types.String   `tfsdk:"name"`
+	Tags           []types.String `tfsdk:"tags"`
+	StorageClasses []types.String `tfsdk:"storage_classes"`
+}
+
+func (d *RgwZoneGroupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_zonegroup"
+}
+
+func (d *RgwZoneGroupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{ // request
+				Optional: true,
+				Computed: true,
+			},
+			"id": schema.StringAttribute{ // response
+				Computed: true,
+			},
+			"api_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"is_master": schema.BoolAttribute{
+				Computed: true,
+			},
+			"realm_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"master_zone_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"default_placement": schema.StringAttribute{
+				Computed: true,
+			},
+			"endpoints": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"zones": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"endpoints": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"tier_type": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"placement_targets": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"tags": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"storage_classes": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *RgwZoneGroupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.clientLibs = clientLibs
+}
+
+func (d *RgwZoneGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RgwZoneGroupDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneGroups, err := d.clientLibs.Admin.GetZoneGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get zonegroups", err.Error())
+		return
+	}
+
+	var zoneGroup lib.RgwZoneGroup
+	var found bool
+
+	if !data.Name.IsNull() {
+		zoneGroup, found = zoneGroups.FindZoneGroup(data.Name.ValueString())
+		if !found {
+			resp.Diagnostics.AddError("Zonegroup not found", "Zonegroup "+data.Name.ValueString()+" does not exist in the current period")
+			return
+		}
+	} else {
+		// Default to the zonegroup of the zone served by the endpoint
+		zone, err := d.clientLibs.Admin.GetZone(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get zone", err.Error())
+			return
+		}
+
+		zoneGroup, found = zoneGroups.FindZoneGroupOfZone(zone.Id)
+		if !found {
+			resp.Diagnostics.AddError("Zonegroup not found", "No zonegroup contains zone "+zone.Name)
+			return
+		}
+	}
+
+	data.Name = types.StringValue(zoneGroup.Name)
+	data.Id = types.StringValue(zoneGroup.Id)
+	data.ApiName = types.StringValue(zoneGroup.ApiName)
+	data.IsMaster = types.BoolValue(zoneGroup.Id == zoneGroups.MasterZoneGroup)
+	data.RealmId = types.StringValue(zoneGroup.RealmId)
+	data.MasterZoneId = types.StringValue(zoneGroup.MasterZone)
+	data.DefaultPlacement = types.StringValue(zoneGroup.GetDefaultPlacement())
+	data.Endpoints = toStringValues(zoneGroup.Endpoints)
+
+	data.Zones = []RgwZoneGroupZoneModel{}
+	for _, zone := range zoneGroup.Zones {
+		data.Zones = append(data.Zones, RgwZoneGroupZoneModel{
+			Id:        types.StringValue(zone.Id),
+			Name:      types.StringValue(zone.Name),
+			Endpoints: toStringValues(zone.Endpoints),
+			TierType:  types.StringValue(zone.TierType),
+		})
+	}
+
+	data.PlacementTargets = []RgwPlacementTargetModel{}
+	for _, target := range zoneGroup.PlacementTargets {
+		data.PlacementTargets = append(data.PlacementTargets, RgwPlacementTargetModel{
+			Name:           types.StringValue(target.Name),
+			Tags:           toStringValues(target.Tags),
+			StorageClasses: toStringValues(target.GetStorageClasses()),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func toStringValues(values []string) []types.String {
+	result := []types.String{}
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
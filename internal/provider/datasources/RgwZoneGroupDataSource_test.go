@@ -0,0 +1,36 @@
+package datasources_test
+
+import (
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwZoneGroupDataSource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+data "ceph_rgw_zonegroup" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "name", "default"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "api_name", "default"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "is_master", "true"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "default_placement", "default-placement"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "endpoints.0", server.URL),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "zones.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "zones.0.name", "default"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "placement_targets.#", "1"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "placement_targets.0.name", "default-placement"),
+					resource.TestCheckResourceAttr("data.ceph_rgw_zonegroup.test", "placement_targets.0.storage_classes.0", "STANDARD"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,388 @@
+// Package fakergw implements an in-process RGW, serving the parts of the admin
+// API and of S3 the provider calls, so that the provider can be tested without
+// a Ceph cluster.
+package fakergw
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-ceph/internal/provider/lib"
+)
+
+const (
+	// AdminUserId is the user owning the keys of Server, with every admin
+	// capability.
+	AdminUserId = "admin"
+
+	adminCaps = "accounts=*;buckets=*;metadata=*;ratelimit=*;usage=*;users=*;zone=*"
+
+	defaultMaxBuckets = 1000
+	defaultMaxUsers   = 1000
+	defaultMaxRoles   = 1000
+	defaultMaxGroups  = 1000
+	defaultNumShards  = 11
+
+	realmId     = "5b7b5ff1-6e9c-4c4a-9f1c-1d4b3cbb1f1a"
+	periodId    = "0f3c7f44-2d57-4e63-8a3d-8e0f4cf6f1a2"
+	zoneGroupId = "a1d5c7e2-8c55-4b6f-9d2e-3f1c0b7a9e41"
+	zoneId      = "c4f8a9b3-1e2d-4f6a-8b7c-9d0e1f2a3b4c"
+)
+
+// Server is a fake RGW with a single realm, zonegroup and zone, all named
+// "default" but the realm. State is kept in memory and request signatures are
+// not verified, only the access key they name.
+type Server struct {
+	*httptest.Server
+
+	// AccessKey and SecretKey are the keys of the admin user.
+	AccessKey string
+	SecretKey string
+
+	mu         sync.Mutex
+	users      map[string]*user
+	accounts   map[string]*account
+	buckets    map[string]*bucket
+	usage      []usageRecord
+	zoneGroup  lib.RgwZoneGroup
+	rateLimits lib.RgwGlobalRateLimits
+	sequence   int
+}
+
+type user struct {
+	Id          string
+	DisplayName string
+	Email       string
+	Suspended   int
+	MaxBuckets  int
+	OpMask      string
+	Keys        []userKey
+	Caps        []userCap
+	UserQuota   quota
+	BucketQuota quota
+	AccountId   string
+	AccountRoot bool
+	RateLimit   lib.RgwRateLimit
+}
+
+type account struct {
+	Id          string
+	Name        string
+	Email       string
+	MaxUsers    int64
+	MaxRoles    int64
+	MaxGroups   int64
+	MaxBuckets  int64
+	Quota       quota
+	BucketQuota quota
+}
+
+type userKey struct {
+	User      string `json:"user"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+type userCap struct {
+	Type string `json:"type"`
+	Perm string `json:"perm"`
+}
+
+type quota struct {
+	Enabled    bool  `json:"enabled"`
+	CheckOnRaw bool  `json:"check_on_raw"`
+	MaxSize    int64 `json:"max_size"`
+	MaxSizeKb  int64 `json:"max_size_kb"`
+	MaxObjects int64 `json:"max_objects"`
+}
+
+type bucket struct {
+	Name          string
+	Id            string
+	Owner         string
+	PlacementRule string
+	NumShards     uint64
+	CreationTime  time.Time
+	Versioning    string
+	Policy        []byte
+	Lifecycle     []byte
+	Cors          []byte
+	Tags          []tag
+	Quota         quota
+	RateLimit     lib.RgwRateLimit
+	Objects       map[string]*object
+}
+
+type object struct {
+	Body                 []byte
+	ContentType          string
+	Etag                 string
+	LastModified         time.Time
+	Metadata             map[string]string
+	Tags                 []tag
+	StorageClass         string
+	ServerSideEncryption string
+	KmsKeyId             string
+	VersionId            string
+}
+
+type tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type usageRecord struct {
+	User          string
+	Bucket        string
+	Owner         string
+	Category      string
+	Time          time.Time
+	BytesSent     uint64
+	BytesReceived uint64
+	Successful    bool
+}
+
+// NewServer starts a fake RGW. It must be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		users:    map[string]*user{},
+		accounts: map[string]*account{},
+		buckets:  map[string]*bucket{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	s.zoneGroup = lib.RgwZoneGroup{
+		Id:         zoneGroupId,
+		Name:       "default",
+		ApiName:    "default",
+		Endpoints:  []string{s.URL},
+		MasterZone: zoneId,
+		Zones: []lib.RgwZoneGroupZone{{
+			Id:        zoneId,
+			Name:      "default",
+			Endpoints: []string{s.URL},
+		}},
+		PlacementTargets: []lib.RgwPlacementTarget{{
+			Name:           lib.RgwDefaultPlacement,
+			Tags:           []string{},
+			StorageClasses: []string{lib.RgwStandardStorageClass},
+		}},
+		DefaultPlacement: lib.RgwDefaultPlacement,
+		RealmId:          realmId,
+	}
+
+	s.AccessKey, s.SecretKey = s.AddUser(AdminUserId, adminCaps)
+	return s
+}
+
+// AddUser creates a user with caps, given as "users=read;buckets=*", and
+// returns its keys.
+func (s *Server) AddUser(id string, caps string) (string, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := newUser(id)
+	u.DisplayName = id
+	u.Caps = mergeCaps(nil, caps)
+	key := s.generateKey(id)
+	u.Keys = []userKey{key}
+	s.users[id] = u
+	return key.AccessKey, key.SecretKey
+}
+
+// AddAccount creates an account users can be attached to.
+func (s *Server) AddAccount(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts[id] = newAccount(id, id)
+}
+
+// HasAccount reports whether the account exists.
+func (s *Server) HasAccount(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.accounts[id] != nil
+}
+
+// HasUser reports whether the user exists.
+func (s *Server) HasUser(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.users[id] != nil
+}
+
+func newUser(id string) *user {
+	return &user{
+		Id:          id,
+		MaxBuckets:  defaultMaxBuckets,
+		OpMask:      "read, write, delete",
+		UserQuota:   disabledQuota(),
+		BucketQuota: disabledQuota(),
+	}
+}
+
+func newAccount(id string, name string) *account {
+	return &account{
+		Id:          id,
+		Name:        name,
+		MaxUsers:    defaultMaxUsers,
+		MaxRoles:    defaultMaxRoles,
+		MaxGroups:   defaultMaxGroups,
+		MaxBuckets:  defaultMaxBuckets,
+		Quota:       disabledQuota(),
+		BucketQuota: disabledQuota(),
+	}
+}
+
+func disabledQuota() quota {
+	return quota{MaxSize: -1, MaxSizeKb: 0, MaxObjects: -1}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sequence++
+	w.Header().Set("X-Amz-Request-Id", s.requestId())
+
+	if strings.HasPrefix(r.URL.Path, "/admin/") {
+		s.serveAdmin(w, r)
+		return
+	}
+	s.serveS3(w, r)
+}
+
+func (s *Server) requestId() string {
+	return fmt.Sprintf("tx%021x-%010x-fake", s.sequence, time.Now().Unix())
+}
+
+// authenticate returns the user whose access key signed the request, nil
+// when the key is unknown, and whether the request was signed at all.
+func (s *Server) authenticate(r *http.Request) (*user, bool) {
+	accessKey := accessKeyOf(r)
+	if accessKey == "" {
+		return nil, false
+	}
+
+	u, _ := s.userByAccessKey(accessKey)
+	return u, true
+}
+
+// accessKeyOf reads the access key from the SigV4 credential scope, sent in
+// the Authorization header or in the query of presigned requests.
+func accessKeyOf(r *http.Request) string {
+	credential := r.URL.Query().Get("X-Amz-Credential")
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		_, credential, _ = strings.Cut(authorization, "Credential=")
+	}
+	accessKey, _, _ := strings.Cut(credential, "/")
+	return accessKey
+}
+
+func (s *Server) userByAccessKey(accessKey string) (*user, userKey) {
+	for _, u := range s.users {
+		for _, key := range u.Keys {
+			if key.AccessKey == accessKey {
+				return u, key
+			}
+		}
+	}
+	return nil, userKey{}
+}
+
+func (s *Server) generateKey(uid string) userKey {
+	for {
+		key := userKey{
+			User:      uid,
+			AccessKey: strings.ToUpper(randomString(10)),
+			SecretKey: randomString(20),
+		}
+		if u, _ := s.userByAccessKey(key.AccessKey); u == nil {
+			return key
+		}
+	}
+}
+
+func randomString(size int) string {
+	buffer := make([]byte, size)
+	if _, err := rand.Read(buffer); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buffer)
+}
+
+// mergeCaps adds caps, given as "users=read;buckets=*", to existing.
+func mergeCaps(existing []userCap, caps string) []userCap {
+	for _, spec := range strings.Split(caps, ";") {
+		capType, perm, found := strings.Cut(strings.TrimSpace(spec), "=")
+		if !found {
+			continue
+		}
+		existing = removeCaps(existing, capType)
+		existing = append(existing, userCap{Type: strings.TrimSpace(capType), Perm: strings.TrimSpace(perm)})
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i].Type < existing[j].Type })
+	return existing
+}
+
+func removeCaps(existing []userCap, capType string) []userCap {
+	var result []userCap
+	for _, c := range existing {
+		if c.Type != strings.TrimSpace(capType) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// allowed reports whether u holds the cap needed to call the admin API with
+// method: read for GET, write otherwise.
+func (u *user) allowed(capType string, method string) bool {
+	for _, c := range u.Caps {
+		if c.Type != capType {
+			continue
+		}
+		if c.Perm == "*" || (strings.Contains(c.Perm, "read") && strings.Contains(c.Perm, "write")) {
+			return true
+		}
+		if method == http.MethodGet || method == http.MethodHead {
+			return strings.Contains(c.Perm, "read")
+		}
+		return strings.Contains(c.Perm, "write")
+	}
+	return false
+}
+
+// hasMarker reports whether the query carries a bare sub-resource marker,
+// such as "?versioning".
+func hasMarker(query url.Values, name string) bool {
+	return query.Has(name) && query.Get(name) == ""
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if value != nil {
+		_ = json.NewEncoder(w).Encode(value)
+	}
+}
+
+func writeXML(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(value)
+}
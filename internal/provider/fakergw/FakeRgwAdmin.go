@@ -0,0 +1,1094 @@
+package fakergw
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"terraform-provider-ceph/internal/provider/lib"
+)
+
+const rgwUsageTimeLayout = "2006-01-02 15:04:05"
+
+// adminCapTypes maps the entry points of the admin API to the cap they
+// require.
+var adminCapTypes = map[string]string{
+	"user":      "users",
+	"account":   "accounts",
+	"ratelimit": "ratelimit",
+	"bucket":    "buckets",
+	"metadata":  "metadata",
+	"usage":     "usage",
+	"realm":     "zone",
+	"config":    "zone",
+}
+
+type adminError struct {
+	Code      string `json:"Code"`
+	RequestId string `json:"RequestId"`
+	HostId    string `json:"HostId"`
+}
+
+type userInfo struct {
+	UserId              string        `json:"user_id"`
+	DisplayName         string        `json:"display_name"`
+	Email               string        `json:"email"`
+	Suspended           int           `json:"suspended"`
+	MaxBuckets          int           `json:"max_buckets"`
+	Subusers            []interface{} `json:"subusers"`
+	Keys                []userKey     `json:"keys"`
+	SwiftKeys           []interface{} `json:"swift_keys"`
+	Caps                []userCap     `json:"caps"`
+	OpMask              string        `json:"op_mask"`
+	DefaultPlacement    string        `json:"default_placement"`
+	DefaultStorageClass string        `json:"default_storage_class"`
+	PlacementTags       []string      `json:"placement_tags"`
+	BucketQuota         quota         `json:"bucket_quota"`
+	UserQuota           quota         `json:"user_quota"`
+	TempURLKeys         []interface{} `json:"temp_url_keys"`
+	Type                string        `json:"type"`
+	MfaIds              []string      `json:"mfa_ids"`
+	AccountId           string        `json:"account_id"`
+}
+
+type accountInfo struct {
+	Id            string `json:"id"`
+	Tenant        string `json:"tenant"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Quota         quota  `json:"quota"`
+	BucketQuota   quota  `json:"bucket_quota"`
+	MaxUsers      int64  `json:"max_users"`
+	MaxRoles      int64  `json:"max_roles"`
+	MaxGroups     int64  `json:"max_groups"`
+	MaxBuckets    int64  `json:"max_buckets"`
+	MaxAccessKeys int64  `json:"max_access_keys"`
+}
+
+type userQuota struct {
+	quota
+	UserId string `json:"user_id"`
+}
+
+type bucketInfo struct {
+	Bucket            string `json:"bucket"`
+	NumShards         uint64 `json:"num_shards"`
+	Tenant            string `json:"tenant"`
+	Zonegroup         string `json:"zonegroup"`
+	PlacementRule     string `json:"placement_rule"`
+	ExplicitPlacement struct {
+		DataPool      string `json:"data_pool"`
+		DataExtraPool string `json:"data_extra_pool"`
+		IndexPool     string `json:"index_pool"`
+	} `json:"explicit_placement"`
+	Id                string                 `json:"id"`
+	Marker            string                 `json:"marker"`
+	IndexType         string                 `json:"index_type"`
+	Versioning        string                 `json:"versioning"`
+	ObjectLockEnabled bool                   `json:"object_lock_enabled"`
+	Owner             string                 `json:"owner"`
+	Ver               string                 `json:"ver"`
+	MasterVer         string                 `json:"master_ver"`
+	Mtime             string                 `json:"mtime"`
+	CreationTime      string                 `json:"creation_time"`
+	MaxMarker         string                 `json:"max_marker"`
+	Usage             map[string]bucketUsage `json:"usage"`
+	BucketQuota       quota                  `json:"bucket_quota"`
+}
+
+type bucketUsage struct {
+	Size           uint64 `json:"size"`
+	SizeActual     uint64 `json:"size_actual"`
+	SizeUtilized   uint64 `json:"size_utilized"`
+	SizeKb         uint64 `json:"size_kb"`
+	SizeKbActual   uint64 `json:"size_kb_actual"`
+	SizeKbUtilized uint64 `json:"size_kb_utilized"`
+	NumObjects     uint64 `json:"num_objects"`
+}
+
+type usageCounters struct {
+	BytesSent     uint64 `json:"bytes_sent"`
+	BytesReceived uint64 `json:"bytes_received"`
+	Ops           uint64 `json:"ops"`
+	SuccessfulOps uint64 `json:"successful_ops"`
+}
+
+type usageCategory struct {
+	Category string `json:"category"`
+	usageCounters
+}
+
+type usageBucket struct {
+	Bucket     string          `json:"bucket"`
+	Time       string          `json:"time"`
+	Epoch      int64           `json:"epoch"`
+	Owner      string          `json:"owner"`
+	Categories []usageCategory `json:"categories"`
+}
+
+type usageEntry struct {
+	User    string        `json:"user"`
+	Buckets []usageBucket `json:"buckets"`
+}
+
+type usageSummary struct {
+	User       string          `json:"user"`
+	Categories []usageCategory `json:"categories"`
+	Total      usageCounters   `json:"total"`
+}
+
+func (s *Server) serveAdmin(w http.ResponseWriter, r *http.Request) {
+	requester, signed := s.authenticate(r)
+	if !signed {
+		s.adminError(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	if requester == nil {
+		s.adminError(w, http.StatusForbidden, "InvalidAccessKeyId")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/admin")
+	query := r.URL.Query()
+
+	entryPoint, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !requester.allowed(adminCapTypes[entryPoint], r.Method) {
+		s.adminError(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	switch {
+	case path == "/user" && hasMarker(query, "key"):
+		s.handleUserKey(w, r.Method, query)
+	case path == "/user" && hasMarker(query, "caps"):
+		s.handleUserCaps(w, r.Method, query)
+	case path == "/user" && hasMarker(query, "quota"):
+		s.handleUserQuota(w, r.Method, query)
+	case path == "/user":
+		s.handleUser(w, r.Method, query)
+	case path == "/account" && hasMarker(query, "quota"):
+		s.handleAccountQuota(w, r.Method, query)
+	case path == "/account":
+		s.handleAccount(w, r.Method, query)
+	case path == "/ratelimit":
+		s.handleRateLimit(w, r.Method, query)
+	case path == "/bucket" && hasMarker(query, "quota"):
+		s.handleBucketQuota(w, r.Method, query)
+	case path == "/bucket" && hasMarker(query, "policy"):
+		s.handleBucketPolicy(w, r.Method, query)
+	case path == "/bucket":
+		s.handleBucket(w, r.Method, query)
+	case path == "/metadata/user":
+		s.handleUserMetadata(w, r.Method)
+	case path == "/metadata/bucket.instance":
+		s.handleBucketInstanceMetadata(w, r.Method, query)
+	case path == "/usage":
+		s.handleUsage(w, r.Method, query)
+	case path == "/realm":
+		s.handleRealm(w, r.Method, query)
+	case path == "/realm/period":
+		s.handlePeriod(w, r.Method, query)
+	case path == "/config":
+		s.handleConfig(w, r.Method, query)
+	default:
+		s.adminError(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (s *Server) adminError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, adminError{
+		Code:      code,
+		RequestId: w.Header().Get("X-Amz-Request-Id"),
+		HostId:    "fake-default-default",
+	})
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, method string, query url.Values) {
+	switch method {
+	case http.MethodGet:
+		u := s.users[query.Get("uid")]
+		if accessKey := query.Get("access-key"); accessKey != "" {
+			u, _ = s.userByAccessKey(accessKey)
+		}
+		if u == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchUser")
+			return
+		}
+		writeJSON(w, http.StatusOK, u.info())
+
+	case http.MethodPut:
+		uid := query.Get("uid")
+		if uid == "" || query.Get("display-name") == "" {
+			s.adminError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		if s.users[uid] != nil {
+			s.adminError(w, http.StatusConflict, "UserAlreadyExists")
+			return
+		}
+
+		u := newUser(uid)
+		if code := s.modifyUser(u, query, query.Get("generate-key") != "false"); code != "" {
+			s.adminError(w, http.StatusConflict, code)
+			return
+		}
+		u.Caps = mergeCaps(nil, query.Get("user-caps"))
+		s.users[uid] = u
+		writeJSON(w, http.StatusOK, u.info())
+
+	case http.MethodPost:
+		u := s.users[query.Get("uid")]
+		if u == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchUser")
+			return
+		}
+		if id := query.Get("account-id"); id != "" && s.accounts[id] == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		if code := s.modifyUser(u, query, query.Get("generate-key") == "true"); code != "" {
+			s.adminError(w, http.StatusConflict, code)
+			return
+		}
+		if query.Has("account-id") {
+			u.AccountId = query.Get("account-id")
+			u.AccountRoot = query.Get("account-root") == "true"
+		}
+		writeJSON(w, http.StatusOK, u.info())
+
+	case http.MethodDelete:
+		uid := query.Get("uid")
+		if s.users[uid] == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchUser")
+			return
+		}
+		if isTrue(query.Get("purge-data")) {
+			for name, b := range s.buckets {
+				if b.Owner == uid {
+					delete(s.buckets, name)
+				}
+			}
+		}
+		delete(s.users, uid)
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// modifyUser applies the user fields of query to u, and adds the key it
+// names or a generated one. It returns the error code of a conflict.
+func (s *Server) modifyUser(u *user, query url.Values, generateKey bool) string {
+	if email := query.Get("email"); email != "" {
+		for _, other := range s.users {
+			if other.Id != u.Id && other.Email == email {
+				return "EmailExists"
+			}
+		}
+		u.Email = email
+	}
+	if query.Has("display-name") {
+		u.DisplayName = query.Get("display-name")
+	}
+	if value, err := strconv.Atoi(query.Get("max-buckets")); err == nil {
+		u.MaxBuckets = value
+	}
+	if query.Has("suspended") {
+		u.Suspended = 0
+		if isTrue(query.Get("suspended")) {
+			u.Suspended = 1
+		}
+	}
+	if query.Has("op-mask") {
+		u.OpMask = query.Get("op-mask")
+	}
+
+	return s.addKey(u, query, generateKey)
+}
+
+// addKey adds the key named by the access-key and secret-key parameters of
+// query to u, generating the missing half, or a whole key when generateKey
+// is set. It returns the error code of a conflict.
+func (s *Server) addKey(u *user, query url.Values, generateKey bool) string {
+	accessKey := query.Get("access-key")
+	secretKey := query.Get("secret-key")
+	if accessKey == "" && secretKey == "" && !generateKey {
+		return ""
+	}
+
+	key := s.generateKey(u.Id)
+	if secretKey != "" {
+		key.SecretKey = secretKey
+	}
+	if accessKey == "" {
+		u.Keys = append(u.Keys, key)
+		return ""
+	}
+
+	owner, _ := s.userByAccessKey(accessKey)
+	if owner != nil && owner != u {
+		return "KeyExists"
+	}
+	key.AccessKey = accessKey
+	for i := range u.Keys {
+		if u.Keys[i].AccessKey == accessKey {
+			u.Keys[i] = key
+			return ""
+		}
+	}
+	u.Keys = append(u.Keys, key)
+	return ""
+}
+
+func (s *Server) handleUserKey(w http.ResponseWriter, method string, query url.Values) {
+	u := s.users[query.Get("uid")]
+	if u == nil {
+		s.adminError(w, http.StatusNotFound, "NoSuchUser")
+		return
+	}
+
+	switch method {
+	case http.MethodPut:
+		if code := s.addKey(u, query, query.Get("generate-key") != "false"); code != "" {
+			s.adminError(w, http.StatusConflict, code)
+			return
+		}
+		writeJSON(w, http.StatusOK, append([]userKey{}, u.Keys...))
+
+	case http.MethodDelete:
+		accessKey := query.Get("access-key")
+		for i, key := range u.Keys {
+			if key.AccessKey == accessKey {
+				u.Keys = append(u.Keys[:i], u.Keys[i+1:]...)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		s.adminError(w, http.StatusNotFound, "InvalidAccessKey")
+
+	default:
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (s *Server) handleUserCaps(w http.ResponseWriter, method string, query url.Values) {
+	u := s.users[query.Get("uid")]
+	if u == nil {
+		s.adminError(w, http.StatusNotFound, "NoSuchUser")
+		return
+	}
+	if query.Get("user-caps") == "" {
+		s.adminError(w, http.StatusBadRequest, "InvalidCapability")
+		return
+	}
+
+	switch method {
+	case http.MethodPut:
+		u.Caps = mergeCaps(u.Caps, query.Get("user-caps"))
+	case http.MethodDelete:
+		for _, spec := range strings.Split(query.Get("user-caps"), ";") {
+			capType, _, _ := strings.Cut(spec, "=")
+			u.Caps = removeCaps(u.Caps, capType)
+		}
+	default:
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, append([]userCap{}, u.Caps...))
+}
+
+func (s *Server) handleUserQuota(w http.ResponseWriter, method string, query url.Values) {
+	u := s.users[query.Get("uid")]
+	if u == nil {
+		s.adminError(w, http.StatusNotFound, "NoSuchUser")
+		return
+	}
+
+	var q *quota
+	switch query.Get("quota-type") {
+	case "user":
+		q = &u.UserQuota
+	case "bucket":
+		q = &u.BucketQuota
+	default:
+		s.adminError(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+
+	switch method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, userQuota{quota: *q, UserId: u.Id})
+	case http.MethodPut:
+		setQuota(q, query)
+		w.WriteHeader(http.StatusOK)
+	default:
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func setQuota(q *quota, query url.Values) {
+	if query.Has("enabled") {
+		q.Enabled = isTrue(query.Get("enabled"))
+	}
+	if value, err := strconv.ParseInt(query.Get("max-size"), 10, 64); err == nil {
+		q.MaxSize = value
+		q.MaxSizeKb = max(0, value/1024)
+	}
+	if value, err := strconv.ParseInt(query.Get("max-size-kb"), 10, 64); err == nil {
+		q.MaxSizeKb = value
+		q.MaxSize = value * 1024
+	}
+	if value, err := strconv.ParseInt(query.Get("max-objects"), 10, 64); err == nil {
+		q.MaxObjects = value
+	}
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, method string, query url.Values) {
+	id := query.Get("id")
+
+	switch method {
+	case http.MethodGet:
+		a := s.accounts[id]
+		if a == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		writeJSON(w, http.StatusOK, a.info())
+
+	case http.MethodPost:
+		if query.Get("name") == "" {
+			s.adminError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		if id == "" {
+			id = fmt.Sprintf("RGW%017d", s.sequence)
+		}
+		for _, other := range s.accounts {
+			if other.Id == id || other.Name == query.Get("name") {
+				s.adminError(w, http.StatusConflict, "AccountAlreadyExists")
+				return
+			}
+		}
+
+		a := newAccount(id, query.Get("name"))
+		modifyAccount(a, query)
+		s.accounts[id] = a
+		writeJSON(w, http.StatusOK, a.info())
+
+	case http.MethodPut:
+		a := s.accounts[id]
+		if a == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		modifyAccount(a, query)
+		writeJSON(w, http.StatusOK, a.info())
+
+	case http.MethodDelete:
+		if s.accounts[id] == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		delete(s.accounts, id)
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// modifyAccount applies the account fields of query to a.
+func modifyAccount(a *account, query url.Values) {
+	if query.Has("name") {
+		a.Name = query.Get("name")
+	}
+	if query.Has("email") {
+		a.Email = query.Get("email")
+	}
+	for name, field := range map[string]*int64{
+		"max-users":   &a.MaxUsers,
+		"max-roles":   &a.MaxRoles,
+		"max-groups":  &a.MaxGroups,
+		"max-buckets": &a.MaxBuckets,
+	} {
+		if value, err := strconv.ParseInt(query.Get(name), 10, 64); err == nil {
+			*field = value
+		}
+	}
+}
+
+func (s *Server) handleAccountQuota(w http.ResponseWriter, method string, query url.Values) {
+	a := s.accounts[query.Get("id")]
+	if a == nil {
+		s.adminError(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	var q *quota
+	switch query.Get("quota-type") {
+	case "account":
+		q = &a.Quota
+	case "bucket":
+		q = &a.BucketQuota
+	default:
+		s.adminError(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+
+	if method != http.MethodPut {
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+	setQuota(q, query)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *account) info() accountInfo {
+	return accountInfo{
+		Id:            a.Id,
+		Name:          a.Name,
+		Email:         a.Email,
+		Quota:         a.Quota,
+		BucketQuota:   a.BucketQuota,
+		MaxUsers:      a.MaxUsers,
+		MaxRoles:      a.MaxRoles,
+		MaxGroups:     a.MaxGroups,
+		MaxBuckets:    a.MaxBuckets,
+		MaxAccessKeys: 4,
+	}
+}
+
+func (s *Server) handleRateLimit(w http.ResponseWriter, method string, query url.Values) {
+	var limit *lib.RgwRateLimit
+	var key string
+
+	switch {
+	case isTrue(query.Get("global")) && method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.rateLimits)
+		return
+
+	case isTrue(query.Get("global")):
+		switch query.Get("ratelimit-scope") {
+		case lib.RgwRateLimitScopeUser:
+			limit = &s.rateLimits.User
+		case lib.RgwRateLimitScopeBucket:
+			limit = &s.rateLimits.Bucket
+		case lib.RgwRateLimitScopeAnonymous:
+			limit = &s.rateLimits.Anonymous
+		default:
+			s.adminError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+
+	case query.Get("ratelimit-scope") == lib.RgwRateLimitScopeUser:
+		u := s.users[query.Get("uid")]
+		if u == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchUser")
+			return
+		}
+		limit, key = &u.RateLimit, "user_ratelimit"
+
+	case query.Get("ratelimit-scope") == lib.RgwRateLimitScopeBucket:
+		b := s.buckets[query.Get("bucket")]
+		if b == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchBucket")
+			return
+		}
+		limit, key = &b.RateLimit, "bucket_ratelimit"
+
+	default:
+		s.adminError(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+
+	switch method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]lib.RgwRateLimit{key: *limit})
+	case http.MethodPost:
+		setRateLimit(limit, query)
+		w.WriteHeader(http.StatusOK)
+	default:
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func setRateLimit(limit *lib.RgwRateLimit, query url.Values) {
+	if query.Has("enabled") {
+		limit.Enabled = isTrue(query.Get("enabled"))
+	}
+	for name, field := range map[string]*int64{
+		"max-read-ops":    &limit.MaxReadOps,
+		"max-write-ops":   &limit.MaxWriteOps,
+		"max-read-bytes":  &limit.MaxReadBytes,
+		"max-write-bytes": &limit.MaxWriteBytes,
+	} {
+		if value, err := strconv.ParseInt(query.Get(name), 10, 64); err == nil {
+			*field = value
+		}
+	}
+}
+
+func (s *Server) handleBucket(w http.ResponseWriter, method string, query url.Values) {
+	name := query.Get("bucket")
+
+	switch {
+	case method == http.MethodGet && name == "":
+		uid := query.Get("uid")
+		if uid != "" && s.users[uid] == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchUser")
+			return
+		}
+
+		var names []string
+		for _, b := range s.buckets {
+			if uid == "" || b.Owner == uid {
+				names = append(names, b.Name)
+			}
+		}
+		sort.Strings(names)
+
+		if !isTrue(query.Get("stats")) {
+			writeJSON(w, http.StatusOK, append([]string{}, names...))
+			return
+		}
+		infos := []bucketInfo{}
+		for _, name := range names {
+			infos = append(infos, s.bucketInfo(s.buckets[name]))
+		}
+		writeJSON(w, http.StatusOK, infos)
+
+	case method == http.MethodGet:
+		b := s.buckets[name]
+		if b == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchBucket")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.bucketInfo(b))
+
+	case method == http.MethodDelete:
+		b := s.buckets[name]
+		if b == nil {
+			s.adminError(w, http.StatusNotFound, "NoSuchBucket")
+			return
+		}
+		if len(b.Objects) > 0 && !isTrue(query.Get("purge-objects")) {
+			s.adminError(w, http.StatusConflict, "BucketNotEmpty")
+			return
+		}
+		delete(s.buckets, name)
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (s *Server) handleBucketQuota(w http.ResponseWriter, method string, query url.Values) {
+	if method != http.MethodPut {
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	b := s.buckets[query.Get("bucket")]
+	if b == nil {
+		s.adminError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	setQuota(&b.Quota, query)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleBucketPolicy(w http.ResponseWriter, method string, query url.Values) {
+	if method != http.MethodGet {
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	b := s.buckets[query.Get("bucket")]
+	if b == nil {
+		s.adminError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	var displayName string
+	if owner := s.users[b.Owner]; owner != nil {
+		displayName = owner.DisplayName
+	}
+
+	// The owner has full control
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"acl": map[string]interface{}{
+			"acl_user_map":  []interface{}{map[string]interface{}{"user": b.Owner, "acl": 15}},
+			"acl_group_map": []interface{}{},
+			"grant_map": []interface{}{map[string]interface{}{
+				"id": b.Owner,
+				"grant": map[string]interface{}{
+					"type":       map[string]interface{}{"type": 0},
+					"id":         b.Owner,
+					"email":      "",
+					"permission": map[string]interface{}{"flags": 15},
+					"name":       displayName,
+					"group":      0,
+					"url_spec":   "",
+				},
+			}},
+		},
+		"owner": map[string]interface{}{
+			"id":           b.Owner,
+			"display_name": displayName,
+		},
+	})
+}
+
+func (s *Server) handleUserMetadata(w http.ResponseWriter, method string) {
+	if method != http.MethodGet {
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	uids := []string{}
+	for uid := range s.users {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+	writeJSON(w, http.StatusOK, uids)
+}
+
+func (s *Server) handleBucketInstanceMetadata(w http.ResponseWriter, method string, query url.Values) {
+	if method != http.MethodGet {
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	name, id, _ := strings.Cut(query.Get("key"), ":")
+	b := s.buckets[name]
+	if b == nil || b.Id != id {
+		s.adminError(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"key": name + ":" + id,
+		"ver": map[string]interface{}{"tag": "_fake", "ver": 1},
+		"data": map[string]interface{}{
+			"bucket_info": map[string]interface{}{
+				"bucket":         map[string]interface{}{"name": name, "bucket_id": id, "marker": id},
+				"owner":          b.Owner,
+				"num_shards":     b.NumShards,
+				"placement_rule": b.PlacementRule,
+				"reshard_status": 0,
+			},
+		},
+	})
+}
+
+func (s *Server) bucketInfo(b *bucket) bucketInfo {
+	info := bucketInfo{
+		Bucket:        b.Name,
+		NumShards:     b.NumShards,
+		Zonegroup:     s.zoneGroup.Id,
+		PlacementRule: b.PlacementRule,
+		Id:            b.Id,
+		Marker:        b.Id,
+		IndexType:     "Normal",
+		Versioning:    "off",
+		Owner:         b.Owner,
+		Ver:           "0#1",
+		MasterVer:     "0#0",
+		Mtime:         b.CreationTime.UTC().Format("2006-01-02T15:04:05.000000Z"),
+		CreationTime:  b.CreationTime.UTC().Format(time.RFC3339Nano),
+		Usage:         map[string]bucketUsage{},
+		BucketQuota:   b.Quota,
+	}
+	if b.Versioning != "" {
+		info.Versioning = strings.ToLower(b.Versioning)
+	}
+
+	if len(b.Objects) > 0 {
+		var usage bucketUsage
+		for _, o := range b.Objects {
+			size := uint64(len(o.Body))
+			usage.Size += size
+			usage.SizeActual += (size + 4095) / 4096 * 4096
+			usage.NumObjects++
+		}
+		usage.SizeUtilized = usage.Size
+		usage.SizeKb = (usage.Size + 1023) / 1024
+		usage.SizeKbActual = usage.SizeActual / 1024
+		usage.SizeKbUtilized = usage.SizeKb
+		info.Usage["rgw.main"] = usage
+	}
+
+	return info
+}
+
+func (s *Server) handleUsage(w http.ResponseWriter, method string, query url.Values) {
+	uid := query.Get("uid")
+	start, startErr := parseUsageTime(query.Get("start"))
+	end, endErr := parseUsageTime(query.Get("end"))
+	if startErr != nil || endErr != nil {
+		s.adminError(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+
+	matches := func(record usageRecord) bool {
+		return (uid == "" || record.User == uid) &&
+			(start.IsZero() || !record.Time.Before(start)) &&
+			(end.IsZero() || record.Time.Before(end))
+	}
+
+	switch method {
+	case http.MethodGet:
+	case http.MethodDelete:
+		var kept []usageRecord
+		for _, record := range s.usage {
+			if !matches(record) {
+				kept = append(kept, record)
+			}
+		}
+		s.usage = kept
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	// Records are summed up per user, per bucket and hour, and per category
+	type bucketHour struct {
+		bucket string
+		owner  string
+		hour   time.Time
+	}
+	entries := map[string]map[bucketHour]map[string]*usageCounters{}
+	for _, record := range s.usage {
+		if !matches(record) {
+			continue
+		}
+		key := bucketHour{bucket: record.Bucket, owner: record.Owner, hour: record.Time.UTC().Truncate(time.Hour)}
+		if entries[record.User] == nil {
+			entries[record.User] = map[bucketHour]map[string]*usageCounters{}
+		}
+		if entries[record.User][key] == nil {
+			entries[record.User][key] = map[string]*usageCounters{}
+		}
+		counters := entries[record.User][key][record.Category]
+		if counters == nil {
+			counters = &usageCounters{}
+			entries[record.User][key][record.Category] = counters
+		}
+		counters.BytesSent += record.BytesSent
+		counters.BytesReceived += record.BytesReceived
+		counters.Ops++
+		if record.Successful {
+			counters.SuccessfulOps++
+		}
+	}
+
+	var users []string
+	for uid := range entries {
+		users = append(users, uid)
+	}
+	sort.Strings(users)
+
+	usageEntries := []usageEntry{}
+	usageSummaries := []usageSummary{}
+	for _, uid := range users {
+		var keys []bucketHour
+		for key := range entries[uid] {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if !keys[i].hour.Equal(keys[j].hour) {
+				return keys[i].hour.Before(keys[j].hour)
+			}
+			return keys[i].bucket < keys[j].bucket
+		})
+
+		entry := usageEntry{User: uid, Buckets: []usageBucket{}}
+		summary := map[string]*usageCounters{}
+		var total usageCounters
+		for _, key := range keys {
+			entry.Buckets = append(entry.Buckets, usageBucket{
+				Bucket:     key.bucket,
+				Time:       key.hour.Format("2006-01-02T15:04:05.000000Z"),
+				Epoch:      key.hour.Unix(),
+				Owner:      key.owner,
+				Categories: toUsageCategories(entries[uid][key]),
+			})
+			for category, counters := range entries[uid][key] {
+				if summary[category] == nil {
+					summary[category] = &usageCounters{}
+				}
+				summary[category].add(*counters)
+				total.add(*counters)
+			}
+		}
+
+		usageEntries = append(usageEntries, entry)
+		usageSummaries = append(usageSummaries, usageSummary{
+			User:       uid,
+			Categories: toUsageCategories(summary),
+			Total:      total,
+		})
+	}
+
+	response := map[string]interface{}{}
+	if query.Get("show-entries") != "false" {
+		response["entries"] = usageEntries
+	}
+	if query.Get("show-summary") != "false" {
+		response["summary"] = usageSummaries
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (c *usageCounters) add(other usageCounters) {
+	c.BytesSent += other.BytesSent
+	c.BytesReceived += other.BytesReceived
+	c.Ops += other.Ops
+	c.SuccessfulOps += other.SuccessfulOps
+}
+
+func toUsageCategories(counters map[string]*usageCounters) []usageCategory {
+	categories := []usageCategory{}
+	for category, value := range counters {
+		categories = append(categories, usageCategory{Category: category, usageCounters: *value})
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Category < categories[j].Category })
+	return categories
+}
+
+func parseUsageTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if parsed, err := time.Parse(rgwUsageTimeLayout, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func (s *Server) handleRealm(w http.ResponseWriter, method string, query url.Values) {
+	if method != http.MethodGet {
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	realm := s.realm()
+	if (query.Has("id") && query.Get("id") != realm.Id) || (query.Has("name") && query.Get("name") != realm.Name) {
+		s.adminError(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+	writeJSON(w, http.StatusOK, realm)
+}
+
+func (s *Server) handlePeriod(w http.ResponseWriter, method string, query url.Values) {
+	if method != http.MethodGet {
+		s.adminError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	if query.Has("realm_id") && query.Get("realm_id") != realmId {
+		s.adminError(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	period := lib.RgwPeriod{
+		Id:              periodId,
+		Epoch:           1,
+		MasterZoneGroup: s.zoneGroup.Id,
+		MasterZone:      zoneId,
+		RealmId:         realmId,
+		RealmEpoch:      1,
+	}
+	period.PeriodMap.Id = periodId
+	period.PeriodMap.ZoneGroups = []lib.RgwZoneGroup{s.zoneGroup}
+	writeJSON(w, http.StatusOK, period)
+}
+
+func (s *Server) handleConfig(w http.ResponseWriter, method string, query url.Values) {
+	if method != http.MethodGet {
+		s.adminError(w, http.StatusNotImplemented, "NotImplemented")
+		return
+	}
+
+	switch query.Get("type") {
+	case "zone":
+		writeJSON(w, http.StatusOK, s.zone())
+	case "zonegroup":
+		writeJSON(w, http.StatusOK, s.zoneGroup)
+	case "zonegroup-map":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"zonegroups":       []interface{}{map[string]interface{}{"key": s.zoneGroup.Id, "val": s.zoneGroup}},
+			"master_zonegroup": s.zoneGroup.Id,
+		})
+	default:
+		s.adminError(w, http.StatusBadRequest, "InvalidArgument")
+	}
+}
+
+func (s *Server) realm() lib.RgwRealm {
+	return lib.RgwRealm{
+		Id:            realmId,
+		Name:          "fake",
+		CurrentPeriod: periodId,
+		Epoch:         1,
+	}
+}
+
+func (s *Server) zone() lib.RgwZone {
+	return lib.RgwZone{
+		Id:      zoneId,
+		Name:    "default",
+		RealmId: realmId,
+		PlacementPools: []lib.RgwZonePlacementPoolEntry{{
+			Key: lib.RgwDefaultPlacement,
+			Val: lib.RgwZonePlacementPool{
+				IndexPool: "default.rgw.buckets.index",
+				StorageClasses: map[string]lib.RgwZoneStorageClass{
+					lib.RgwStandardStorageClass: {DataPool: "default.rgw.buckets.data"},
+				},
+				DataExtraPool: "default.rgw.buckets.non-ec",
+			},
+		}},
+	}
+}
+
+func (u *user) info() userInfo {
+	userType := "rgw"
+	if u.AccountRoot {
+		userType = "root"
+	}
+
+	return userInfo{
+		UserId:        u.Id,
+		DisplayName:   u.DisplayName,
+		Email:         u.Email,
+		Suspended:     u.Suspended,
+		MaxBuckets:    u.MaxBuckets,
+		Subusers:      []interface{}{},
+		Keys:          append([]userKey{}, u.Keys...),
+		SwiftKeys:     []interface{}{},
+		Caps:          append([]userCap{}, u.Caps...),
+		OpMask:        u.OpMask,
+		PlacementTags: []string{},
+		BucketQuota:   u.BucketQuota,
+		UserQuota:     u.UserQuota,
+		TempURLKeys:   []interface{}{},
+		Type:          userType,
+		MfaIds:        []string{},
+		AccountId:     u.AccountId,
+	}
+}
+
+func isTrue(value string) bool {
+	parsed, err := strconv.ParseBool(value)
+	return err == nil && parsed
+}
@@ -0,0 +1,734 @@
+package fakergw
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+type s3Error struct {
+	XMLName    xml.Name `xml:"Error"`
+	Code       string   `xml:"Code"`
+	Message    string   `xml:"Message,omitempty"`
+	BucketName string   `xml:"BucketName,omitempty"`
+	RequestId  string   `xml:"RequestId"`
+	HostId     string   `xml:"HostId"`
+}
+
+type s3Owner struct {
+	Id          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name `xml:"ListAllMyBucketsResult"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Owner   s3Owner  `xml:"Owner"`
+	Buckets []struct {
+		Name         string `xml:"Name"`
+		CreationDate string `xml:"CreationDate"`
+	} `xml:"Buckets>Bucket"`
+}
+
+type createBucketConfiguration struct {
+	LocationConstraint string `xml:"LocationConstraint"`
+}
+
+type locationConstraint struct {
+	XMLName xml.Name `xml:"LocationConstraint"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Value   string   `xml:",chardata"`
+}
+
+type versioningConfiguration struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+type tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	TagSet  []tag    `xml:"TagSet>Tag"`
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name `xml:"ListBucketResult"`
+	Xmlns                 string   `xml:"xmlns,attr"`
+	Name                  string   `xml:"Name"`
+	Prefix                string   `xml:"Prefix"`
+	StartAfter            string   `xml:"StartAfter,omitempty"`
+	ContinuationToken     string   `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string   `xml:"NextContinuationToken,omitempty"`
+	KeyCount              int      `xml:"KeyCount"`
+	MaxKeys               int      `xml:"MaxKeys"`
+	Delimiter             string   `xml:"Delimiter,omitempty"`
+	IsTruncated           bool     `xml:"IsTruncated"`
+	Contents              []struct {
+		Key          string `xml:"Key"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int    `xml:"Size"`
+		StorageClass string `xml:"StorageClass"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+}
+
+// statusRecorder keeps what a handler answered, for the usage log.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  uint64
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += uint64(n)
+	return n, err
+}
+
+func (s *Server) serveS3(w http.ResponseWriter, r *http.Request) {
+	requester, signed := s.authenticate(r)
+	switch {
+	case !signed:
+		s.s3Error(w, r, http.StatusForbidden, "AccessDenied", "")
+		return
+	case requester == nil:
+		s.s3Error(w, r, http.StatusForbidden, "InvalidAccessKeyId", "")
+		return
+	case requester.Suspended != 0:
+		s.s3Error(w, r, http.StatusForbidden, "UserSuspended", "")
+		return
+	}
+
+	bucketName, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	var category string
+	switch {
+	case bucketName == "" && r.Method == http.MethodGet:
+		category = "list_buckets"
+		s.listBuckets(recorder, requester)
+	case bucketName == "":
+		s.s3Error(recorder, r, http.StatusMethodNotAllowed, "MethodNotAllowed", "")
+	case key == "":
+		category = s.serveBucket(recorder, r, requester, bucketName)
+	default:
+		category = s.serveObject(recorder, r, requester, bucketName, key)
+	}
+
+	if category == "" {
+		return
+	}
+	record := usageRecord{
+		User:       requester.Id,
+		Bucket:     bucketName,
+		Owner:      requester.Id,
+		Category:   category,
+		Time:       time.Now(),
+		BytesSent:  recorder.bytes,
+		Successful: recorder.status < http.StatusBadRequest,
+	}
+	if b := s.buckets[bucketName]; b != nil {
+		record.Owner = b.Owner
+	}
+	if r.ContentLength > 0 {
+		record.BytesReceived = uint64(r.ContentLength)
+	}
+	if bucketName == "" {
+		record.Bucket = "-"
+	}
+	s.usage = append(s.usage, record)
+}
+
+func (s *Server) s3Error(w http.ResponseWriter, r *http.Request, status int, code string, bucketName string) {
+	if r.Method == http.MethodHead {
+		w.WriteHeader(status)
+		return
+	}
+
+	writeXML(w, status, s3Error{
+		Code:       code,
+		BucketName: bucketName,
+		RequestId:  w.Header().Get("X-Amz-Request-Id"),
+		HostId:     "fake-default-default",
+	})
+}
+
+func (s *Server) listBuckets(w http.ResponseWriter, requester *user) {
+	result := listAllMyBucketsResult{
+		Xmlns: s3Namespace,
+		Owner: s3Owner{Id: requester.Id, DisplayName: requester.DisplayName},
+	}
+
+	var names []string
+	for name, b := range s.buckets {
+		if b.Owner == requester.Id {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		result.Buckets = append(result.Buckets, struct {
+			Name         string `xml:"Name"`
+			CreationDate string `xml:"CreationDate"`
+		}{Name: name, CreationDate: s.buckets[name].CreationTime.UTC().Format("2006-01-02T15:04:05.000Z")})
+	}
+
+	writeXML(w, http.StatusOK, result)
+}
+
+// serveBucket answers the requests on a bucket and its sub-resources, and
+// returns the usage category of the operation.
+func (s *Server) serveBucket(w http.ResponseWriter, r *http.Request, requester *user, name string) string {
+	query := r.URL.Query()
+
+	if r.Method == http.MethodPut && len(query) == 0 {
+		s.createBucket(w, r, requester, name)
+		return "create_bucket"
+	}
+
+	b := s.buckets[name]
+	if b == nil {
+		s.s3Error(w, r, http.StatusNotFound, "NoSuchBucket", name)
+		return "stat_bucket"
+	}
+	if b.Owner != requester.Id {
+		s.s3Error(w, r, http.StatusForbidden, "AccessDenied", name)
+		return "stat_bucket"
+	}
+
+	switch {
+	case hasMarker(query, "versioning"):
+		s.serveBucketVersioning(w, r, b)
+		return "bucket_versioning"
+	case hasMarker(query, "policy"):
+		s.serveBucketDocument(w, r, &b.Policy, "NoSuchBucketPolicy", validPolicy)
+		return "bucket_policy"
+	case hasMarker(query, "lifecycle"):
+		s.serveBucketDocument(w, r, &b.Lifecycle, "NoSuchLifecycleConfiguration", validRules)
+		return "bucket_lifecycle"
+	case hasMarker(query, "cors"):
+		s.serveBucketDocument(w, r, &b.Cors, "NoSuchCORSConfiguration", validRules)
+		return "bucket_cors"
+	case hasMarker(query, "tagging"):
+		s.serveTagging(w, r, &b.Tags, "NoSuchTagSet")
+		return "bucket_tagging"
+	case hasMarker(query, "location") && r.Method == http.MethodGet:
+		writeXML(w, http.StatusOK, locationConstraint{Xmlns: s3Namespace, Value: s.zoneGroup.ApiName})
+		return "get_bucket_location"
+	case len(query) > 0 && !query.Has("list-type") && !query.Has("prefix") && !query.Has("delimiter"):
+		s.s3Error(w, r, http.StatusNotImplemented, "NotImplemented", name)
+		return "unsupported"
+	}
+
+	switch r.Method {
+	case http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+		return "stat_bucket"
+	case http.MethodGet:
+		s.listObjects(w, r, b)
+		return "list_bucket"
+	case http.MethodDelete:
+		if len(b.Objects) > 0 {
+			s.s3Error(w, r, http.StatusConflict, "BucketNotEmpty", name)
+			return "delete_bucket"
+		}
+		delete(s.buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+		return "delete_bucket"
+	default:
+		s.s3Error(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", name)
+		return "unsupported"
+	}
+}
+
+func (s *Server) createBucket(w http.ResponseWriter, r *http.Request, requester *user, name string) {
+	if !bucketNamePattern.MatchString(name) || strings.Contains(name, "..") {
+		s.s3Error(w, r, http.StatusBadRequest, "InvalidBucketName", name)
+		return
+	}
+
+	if existing := s.buckets[name]; existing != nil {
+		if existing.Owner == requester.Id {
+			s.s3Error(w, r, http.StatusConflict, "BucketAlreadyOwnedByYou", name)
+		} else {
+			s.s3Error(w, r, http.StatusConflict, "BucketAlreadyExists", name)
+		}
+		return
+	}
+
+	owned := 0
+	for _, b := range s.buckets {
+		if b.Owner == requester.Id {
+			owned++
+		}
+	}
+	if requester.MaxBuckets > 0 && owned >= requester.MaxBuckets {
+		s.s3Error(w, r, http.StatusBadRequest, "TooManyBuckets", name)
+		return
+	}
+
+	var configuration createBucketConfiguration
+	body, err := readBody(r)
+	if err != nil {
+		s.s3Error(w, r, http.StatusBadRequest, "IncompleteBody", name)
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 && xml.Unmarshal(body, &configuration) != nil {
+		s.s3Error(w, r, http.StatusBadRequest, "MalformedXML", name)
+		return
+	}
+
+	placement, found := s.placementOf(configuration.LocationConstraint)
+	if !found {
+		s.s3Error(w, r, http.StatusBadRequest, "InvalidLocationConstraint", name)
+		return
+	}
+
+	s.buckets[name] = &bucket{
+		Name:          name,
+		Id:            fmt.Sprintf("%s.%d.%d", zoneId, 4100, s.sequence),
+		Owner:         requester.Id,
+		PlacementRule: placement,
+		NumShards:     defaultNumShards,
+		CreationTime:  time.Now(),
+		Quota:         disabledQuota(),
+		Objects:       map[string]*object{},
+	}
+	w.Header().Set("Location", "/"+name)
+	w.WriteHeader(http.StatusOK)
+}
+
+// placementOf resolves a location constraint of the form
+// "<zonegroup>[:<placement>[/<storage class>]]" to the placement rule of the
+// bucket, checking the zonegroup and placement target exist.
+func (s *Server) placementOf(constraint string) (string, bool) {
+	zoneGroup, rule, _ := strings.Cut(constraint, ":")
+	if zoneGroup != "" && zoneGroup != s.zoneGroup.ApiName {
+		return "", false
+	}
+	if rule == "" {
+		return s.zoneGroup.DefaultPlacement, true
+	}
+
+	placement, storageClass, _ := strings.Cut(rule, "/")
+	for _, target := range s.zoneGroup.PlacementTargets {
+		if target.Name != placement {
+			continue
+		}
+		if storageClass == "" {
+			return rule, true
+		}
+		for _, class := range target.StorageClasses {
+			if class == storageClass {
+				return rule, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (s *Server) serveBucketVersioning(w http.ResponseWriter, r *http.Request, b *bucket) {
+	switch r.Method {
+	case http.MethodGet:
+		writeXML(w, http.StatusOK, versioningConfiguration{Xmlns: s3Namespace, Status: b.Versioning})
+
+	case http.MethodPut:
+		var configuration versioningConfiguration
+		body, err := readBody(r)
+		if err != nil || xml.Unmarshal(body, &configuration) != nil {
+			s.s3Error(w, r, http.StatusBadRequest, "MalformedXML", b.Name)
+			return
+		}
+		if configuration.Status != "Enabled" && configuration.Status != "Suspended" {
+			s.s3Error(w, r, http.StatusBadRequest, "MalformedXML", b.Name)
+			return
+		}
+		b.Versioning = configuration.Status
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		s.s3Error(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", b.Name)
+	}
+}
+
+// serveBucketDocument stores and returns a bucket sub-resource as sent by the
+// client, after checking it with valid.
+func (s *Server) serveBucketDocument(w http.ResponseWriter, r *http.Request, document *[]byte, missingCode string, valid func([]byte) (string, bool)) {
+	switch r.Method {
+	case http.MethodGet:
+		if *document == nil {
+			s.s3Error(w, r, http.StatusNotFound, missingCode, "")
+			return
+		}
+		contentType := "application/xml"
+		if json.Valid(*document) {
+			contentType = "application/json"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(*document)
+
+	case http.MethodPut:
+		body, err := readBody(r)
+		if err != nil {
+			s.s3Error(w, r, http.StatusBadRequest, "IncompleteBody", "")
+			return
+		}
+		if code, ok := valid(body); !ok {
+			s.s3Error(w, r, http.StatusBadRequest, code, "")
+			return
+		}
+		*document = body
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodDelete:
+		*document = nil
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		s.s3Error(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", "")
+	}
+}
+
+func validPolicy(body []byte) (string, bool) {
+	var policy struct {
+		Statement []json.RawMessage `json:"Statement"`
+	}
+	if json.Unmarshal(body, &policy) != nil {
+		return "MalformedPolicy", false
+	}
+	return "", true
+}
+
+// validRules checks a lifecycle or CORS configuration holds at least one rule.
+func validRules(body []byte) (string, bool) {
+	var configuration struct {
+		Rules     []struct{} `xml:"Rule"`
+		CorsRules []struct{} `xml:"CORSRule"`
+	}
+	if xml.Unmarshal(body, &configuration) != nil || len(configuration.Rules)+len(configuration.CorsRules) == 0 {
+		return "MalformedXML", false
+	}
+	return "", true
+}
+
+func (s *Server) serveTagging(w http.ResponseWriter, r *http.Request, tags *[]tag, missingCode string) {
+	switch r.Method {
+	case http.MethodGet:
+		if *tags == nil && missingCode != "" {
+			s.s3Error(w, r, http.StatusNotFound, missingCode, "")
+			return
+		}
+		writeXML(w, http.StatusOK, tagging{Xmlns: s3Namespace, TagSet: *tags})
+
+	case http.MethodPut:
+		var configuration tagging
+		body, err := readBody(r)
+		if err != nil || xml.Unmarshal(body, &configuration) != nil {
+			s.s3Error(w, r, http.StatusBadRequest, "MalformedXML", "")
+			return
+		}
+		*tags = append([]tag{}, configuration.TagSet...)
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodDelete:
+		*tags = nil
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		s.s3Error(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", "")
+	}
+}
+
+func (s *Server) listObjects(w http.ResponseWriter, r *http.Request, b *bucket) {
+	query := r.URL.Query()
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+
+	maxKeys := 1000
+	if value, err := strconv.Atoi(query.Get("max-keys")); err == nil && value >= 0 && value < maxKeys {
+		maxKeys = value
+	}
+
+	after := query.Get("start-after")
+	if token := query.Get("continuation-token"); token != "" {
+		after = token
+	}
+	if marker := query.Get("marker"); marker != "" {
+		after = marker
+	}
+
+	result := listBucketResult{
+		Xmlns:             s3Namespace,
+		Name:              b.Name,
+		Prefix:            prefix,
+		StartAfter:        query.Get("start-after"),
+		ContinuationToken: query.Get("continuation-token"),
+		MaxKeys:           maxKeys,
+		Delimiter:         delimiter,
+	}
+
+	var keys []string
+	for key := range b.Objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	last := ""
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) || key <= after {
+			continue
+		}
+		// A token naming a common prefix skips every key under it
+		if delimiter != "" && strings.HasSuffix(after, delimiter) && strings.HasPrefix(key, after) {
+			continue
+		}
+
+		commonPrefix := ""
+		if delimiter != "" {
+			if index := strings.Index(key[len(prefix):], delimiter); index >= 0 {
+				commonPrefix = key[:len(prefix)+index+len(delimiter)]
+			}
+		}
+		if commonPrefix != "" && commonPrefix == last {
+			continue
+		}
+
+		if result.KeyCount == maxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = last
+			break
+		}
+
+		if commonPrefix != "" {
+			result.CommonPrefixes = append(result.CommonPrefixes, struct {
+				Prefix string `xml:"Prefix"`
+			}{Prefix: commonPrefix})
+			last = commonPrefix
+		} else {
+			o := b.Objects[key]
+			result.Contents = append(result.Contents, struct {
+				Key          string `xml:"Key"`
+				LastModified string `xml:"LastModified"`
+				ETag         string `xml:"ETag"`
+				Size         int    `xml:"Size"`
+				StorageClass string `xml:"StorageClass"`
+			}{
+				Key:          key,
+				LastModified: o.LastModified.UTC().Format("2006-01-02T15:04:05.000Z"),
+				ETag:         o.Etag,
+				Size:         len(o.Body),
+				StorageClass: o.StorageClass,
+			})
+			last = key
+		}
+		result.KeyCount++
+	}
+
+	writeXML(w, http.StatusOK, result)
+}
+
+// serveObject answers the requests on an object and its tags, and returns the
+// usage category of the operation.
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, requester *user, bucketName string, key string) string {
+	b := s.buckets[bucketName]
+	if b == nil {
+		s.s3Error(w, r, http.StatusNotFound, "NoSuchBucket", bucketName)
+		return "get_obj"
+	}
+	if b.Owner != requester.Id {
+		s.s3Error(w, r, http.StatusForbidden, "AccessDenied", bucketName)
+		return "get_obj"
+	}
+
+	query := r.URL.Query()
+	o := b.Objects[key]
+
+	if versionId := query.Get("versionId"); versionId != "" && o != nil && versionId != o.VersionId {
+		s.s3Error(w, r, http.StatusNotFound, "NoSuchVersion", bucketName)
+		return "get_obj"
+	}
+
+	switch {
+	case hasMarker(query, "tagging"):
+		if o == nil {
+			s.s3Error(w, r, http.StatusNotFound, "NoSuchKey", bucketName)
+			return "get_obj_tagging"
+		}
+		s.serveTagging(w, r, &o.Tags, "")
+		return "obj_tagging"
+	case hasMarker(query, "uploads") || query.Has("uploadId") || r.Header.Get("X-Amz-Copy-Source") != "":
+		s.s3Error(w, r, http.StatusNotImplemented, "NotImplemented", bucketName)
+		return "unsupported"
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		s.putObject(w, r, b, key)
+		return "put_obj"
+
+	case http.MethodGet, http.MethodHead:
+		if o == nil {
+			s.s3Error(w, r, http.StatusNotFound, "NoSuchKey", bucketName)
+			return "get_obj"
+		}
+
+		header := w.Header()
+		header.Set("Content-Type", o.ContentType)
+		header.Set("ETag", o.Etag)
+		for name, value := range o.Metadata {
+			header.Set("X-Amz-Meta-"+name, value)
+		}
+		if o.VersionId != "" {
+			header.Set("X-Amz-Version-Id", o.VersionId)
+		}
+		if o.StorageClass != "STANDARD" {
+			header.Set("X-Amz-Storage-Class", o.StorageClass)
+		}
+		if o.ServerSideEncryption != "" {
+			header.Set("X-Amz-Server-Side-Encryption", o.ServerSideEncryption)
+		}
+		if o.KmsKeyId != "" {
+			header.Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", o.KmsKeyId)
+		}
+		if len(o.Tags) > 0 {
+			header.Set("X-Amz-Tagging-Count", strconv.Itoa(len(o.Tags)))
+		}
+		http.ServeContent(w, r, "", o.LastModified, bytes.NewReader(o.Body))
+		return "get_obj"
+
+	case http.MethodDelete:
+		delete(b.Objects, key)
+		w.WriteHeader(http.StatusNoContent)
+		return "delete_obj"
+
+	default:
+		s.s3Error(w, r, http.StatusMethodNotAllowed, "MethodNotAllowed", bucketName)
+		return "unsupported"
+	}
+}
+
+func (s *Server) putObject(w http.ResponseWriter, r *http.Request, b *bucket, key string) {
+	body, err := readBody(r)
+	if err != nil {
+		s.s3Error(w, r, http.StatusBadRequest, "IncompleteBody", b.Name)
+		return
+	}
+
+	storageClass := r.Header.Get("X-Amz-Storage-Class")
+	if storageClass == "" {
+		storageClass = "STANDARD"
+	}
+	if _, found := s.placementOf(":" + strings.Split(b.PlacementRule, "/")[0] + "/" + storageClass); !found {
+		s.s3Error(w, r, http.StatusBadRequest, "InvalidStorageClass", b.Name)
+		return
+	}
+
+	var tags []tag
+	if encoded := r.Header.Get("X-Amz-Tagging"); encoded != "" {
+		values, err := url.ParseQuery(encoded)
+		if err != nil {
+			s.s3Error(w, r, http.StatusBadRequest, "InvalidTag", b.Name)
+			return
+		}
+		for name := range values {
+			tags = append(tags, tag{Key: name, Value: values.Get(name)})
+		}
+		sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	}
+
+	metadata := map[string]string{}
+	for name := range r.Header {
+		if strings.HasPrefix(name, "X-Amz-Meta-") {
+			metadata[strings.ToLower(strings.TrimPrefix(name, "X-Amz-Meta-"))] = r.Header.Get(name)
+		}
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "binary/octet-stream"
+	}
+
+	sum := md5.Sum(body)
+	o := &object{
+		Body:                 body,
+		ContentType:          contentType,
+		Etag:                 `"` + hex.EncodeToString(sum[:]) + `"`,
+		LastModified:         time.Now().UTC().Truncate(time.Second),
+		Metadata:             metadata,
+		Tags:                 tags,
+		StorageClass:         storageClass,
+		ServerSideEncryption: r.Header.Get("X-Amz-Server-Side-Encryption"),
+		KmsKeyId:             r.Header.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"),
+	}
+	if b.Versioning == "Enabled" {
+		o.VersionId = randomString(16)
+		w.Header().Set("X-Amz-Version-Id", o.VersionId)
+	}
+	b.Objects[key] = o
+
+	w.Header().Set("ETag", o.Etag)
+	w.WriteHeader(http.StatusOK)
+}
+
+// readBody reads the request payload, decoding the aws-chunked encoding the
+// SDK uses for streamed uploads.
+func readBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(r.Header.Get("Content-Encoding"), "aws-chunked") &&
+		!strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return body, nil
+	}
+
+	var decoded []byte
+	reader := bufio.NewReader(bytes.NewReader(body))
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeField, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeField, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return decoded, nil
+		}
+
+		chunk := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, chunk); err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, chunk[:size]...)
+	}
+}
@@ -0,0 +1,230 @@
+package fakergw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/ceph/go-ceph/rgw/admin"
+)
+
+func newTestClients(t *testing.T) (*Server, *admin.API, *s3.Client) {
+	t.Helper()
+
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	rgw, err := admin.New(server.URL, server.AccessKey, server.SecretKey, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s3Client := s3.New(s3.Options{
+		Region:       "default",
+		BaseEndpoint: aws.String(server.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider(server.AccessKey, server.SecretKey, ""),
+	})
+
+	return server, rgw, s3Client
+}
+
+func TestAdminUserKeysAndCaps(t *testing.T) {
+	ctx := context.Background()
+	_, rgw, _ := newTestClients(t)
+
+	_, err := rgw.CreateUser(ctx, admin.User{ID: "alice", DisplayName: "Alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rgw.CreateUser(ctx, admin.User{ID: "alice", DisplayName: "Alice"})
+	if !errors.Is(err, admin.ErrUserExists) {
+		t.Fatalf("expected %s, got %v", admin.ErrUserExists, err)
+	}
+
+	keys, err := rgw.CreateKey(ctx, admin.UserKeySpec{UID: "alice", AccessKey: "ALICEKEY", SecretKey: "alicesecret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(*keys))
+	}
+
+	user, err := rgw.GetUser(ctx, admin.User{Keys: []admin.UserKeySpec{{AccessKey: "ALICEKEY"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != "alice" {
+		t.Fatalf("expected the key to belong to alice, got %s", user.ID)
+	}
+
+	err = rgw.RemoveKey(ctx, admin.UserKeySpec{UID: "alice", AccessKey: "ALICEKEY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caps, err := rgw.AddUserCap(ctx, "alice", "users=read;buckets=*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(caps) != 2 {
+		t.Fatalf("expected 2 caps, got %v", caps)
+	}
+
+	caps, err = rgw.RemoveUserCap(ctx, "alice", "users=read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(caps) != 1 || caps[0].Type != "buckets" {
+		t.Fatalf("expected only the buckets cap, got %v", caps)
+	}
+
+	err = rgw.RemoveUser(ctx, admin.User{ID: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rgw.GetUser(ctx, admin.User{ID: "alice"})
+	if !errors.Is(err, admin.ErrNoSuchUser) {
+		t.Fatalf("expected %s, got %v", admin.ErrNoSuchUser, err)
+	}
+}
+
+func TestAdminQuotas(t *testing.T) {
+	ctx := context.Background()
+	_, rgw, s3Client := newTestClients(t)
+
+	enabled := true
+	maxObjects := int64(100)
+	err := rgw.SetUserQuota(ctx, admin.QuotaSpec{UID: AdminUserId, QuotaType: "user", Enabled: &enabled, MaxObjects: &maxObjects})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quota, err := rgw.GetUserQuota(ctx, admin.QuotaSpec{UID: AdminUserId, QuotaType: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quota.Enabled == nil || !*quota.Enabled || quota.MaxObjects == nil || *quota.MaxObjects != 100 {
+		t.Fatalf("unexpected user quota %+v", quota)
+	}
+
+	_, err = s3Client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String("quoted")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rgw.SetIndividualBucketQuota(ctx, admin.QuotaSpec{UID: AdminUserId, Bucket: "quoted", Enabled: &enabled, MaxObjects: &maxObjects})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, err := rgw.GetBucketInfo(ctx, admin.Bucket{Bucket: "quoted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bucket.BucketQuota.MaxObjects == nil || *bucket.BucketQuota.MaxObjects != 100 {
+		t.Fatalf("unexpected bucket quota %+v", bucket.BucketQuota)
+	}
+}
+
+func TestAdminAccessDenied(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := newTestClients(t)
+
+	accessKey, secretKey := server.AddUser("reader", "users=read")
+	rgw, err := admin.New(server.URL, accessKey, secretKey, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rgw.GetUser(ctx, admin.User{ID: AdminUserId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rgw.CreateUser(ctx, admin.User{ID: "mallory", DisplayName: "Mallory"})
+	if !errors.Is(err, admin.ErrAccessDenied) {
+		t.Fatalf("expected %s, got %v", admin.ErrAccessDenied, err)
+	}
+}
+
+func TestS3BucketSubresources(t *testing.T) {
+	ctx := context.Background()
+	_, _, s3Client := newTestClients(t)
+
+	_, err := s3Client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String("site")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s3Client.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: aws.String("site")})
+	assertErrorCode(t, err, "NoSuchCORSConfiguration")
+
+	_, err = s3Client.PutBucketCors(ctx, &s3.PutBucketCorsInput{
+		Bucket: aws.String("site"),
+		CORSConfiguration: &s3types.CORSConfiguration{
+			CORSRules: []s3types.CORSRule{{
+				AllowedMethods: []string{"GET"},
+				AllowedOrigins: []string{"https://example.com"},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cors, err := s3Client.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: aws.String("site")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cors.CORSRules) != 1 || cors.CORSRules[0].AllowedOrigins[0] != "https://example.com" {
+		t.Fatalf("unexpected CORS rules %+v", cors.CORSRules)
+	}
+
+	_, err = s3Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String("site")})
+	assertErrorCode(t, err, "NoSuchTagSet")
+
+	_, err = s3Client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
+		Bucket:  aws.String("site"),
+		Tagging: &s3types.Tagging{TagSet: []s3types.Tag{{Key: aws.String("team"), Value: aws.String("web")}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := s3Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String("site")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags.TagSet) != 1 || *tags.TagSet[0].Value != "web" {
+		t.Fatalf("unexpected tags %+v", tags.TagSet)
+	}
+
+	_, err = s3Client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{Bucket: aws.String("site"), Policy: aws.String("not json")})
+	assertErrorCode(t, err, "MalformedPolicy")
+
+	_, err = s3Client.CreateBucket(ctx, &s3.CreateBucketInput{
+		Bucket: aws.String("misplaced"),
+		CreateBucketConfiguration: &s3types.CreateBucketConfiguration{
+			LocationConstraint: "default:fast-placement",
+		},
+	})
+	assertErrorCode(t, err, "InvalidLocationConstraint")
+}
+
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != code {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+}
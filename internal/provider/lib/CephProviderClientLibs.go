@@ -1,11 +1,55 @@
 package lib
 
 import (
-	"github.com/aws/aws-sdk-go/service/s3"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type CephProviderClientLibs struct {
-	S3  *s3.S3
-	Rgw *admin.API
+	S3    *s3.Client
+	IAM   *iam.IAM
+	Rgw   *admin.API
+	Admin *RgwAdminClient
+}
+
+// GetZoneGroup returns the zonegroup served under the configured S3 region,
+// falling back to the zonegroup of the gateway's zone and then to the master
+// zonegroup.
+func (c *CephProviderClientLibs) GetZoneGroup(ctx context.Context) (RgwZoneGroup, bool, error) {
+	zoneGroups, err := c.Admin.GetZoneGroups(ctx)
+	if err != nil {
+		return RgwZoneGroup{}, false, err
+	}
+
+	if zoneGroup, found := zoneGroups.FindZoneGroup(c.S3.Options().Region); found {
+		return zoneGroup, true, nil
+	}
+
+	if zone, err := c.Admin.GetZone(ctx); err == nil {
+		if zoneGroup, found := zoneGroups.FindZoneGroupOfZone(zone.Id); found {
+			return zoneGroup, true, nil
+		}
+	}
+
+	zoneGroup, found := zoneGroups.GetMasterZoneGroup()
+	return zoneGroup, found, nil
+}
+
+// GetDefaultPlacement returns the default placement target of the zonegroup,
+// falling back to RGW's own default when the zonegroup cannot be read.
+func (c *CephProviderClientLibs) GetDefaultPlacement(ctx context.Context) string {
+	zoneGroup, found, err := c.GetZoneGroup(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read zonegroup, assuming "+RgwDefaultPlacement+" is the default placement: "+err.Error())
+		return RgwDefaultPlacement
+	}
+	if !found {
+		return RgwDefaultPlacement
+	}
+
+	return zoneGroup.GetDefaultPlacement()
 }
@@ -0,0 +1,270 @@
+package lib
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/smithy-go"
+	"github.com/ceph/go-ceph/rgw/admin"
+)
+
+// ErrorClass tells how a failed RGW, S3 or IAM call should be handled.
+type ErrorClass int
+
+const (
+	ErrorClassUnknown ErrorClass = iota
+	ErrorClassNotFound
+	ErrorClassConflict
+	ErrorClassThrottled
+	ErrorClassAuth
+	ErrorClassTransient
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassNotFound:
+		return "not found"
+	case ErrorClassConflict:
+		return "conflict"
+	case ErrorClassThrottled:
+		return "throttled"
+	case ErrorClassAuth:
+		return "auth"
+	case ErrorClassTransient:
+		return "transient"
+	default:
+		return "unknown"
+	}
+}
+
+// Error codes returned by RGW that are not declared by go-ceph or the SDK.
+const (
+	ErrCodeNotFound                     = "NotFound"
+	ErrCodeNoSuchUpload                 = "NoSuchUpload"
+	ErrCodeNoSuchBucketPolicy           = "NoSuchBucketPolicy"
+	ErrCodeNoSuchLifecycleConfiguration = "NoSuchLifecycleConfiguration"
+	ErrCodeBucketAlreadyExists          = "BucketAlreadyExists"
+	ErrCodeBucketAlreadyOwnedByYou      = "BucketAlreadyOwnedByYou"
+)
+
+const goCephAdminPackage = "github.com/ceph/go-ceph/rgw/admin"
+
+var errorCodeClasses = map[string]ErrorClass{
+	// Admin API
+	string(admin.ErrNoSuchUser):            ErrorClassNotFound,
+	string(admin.ErrNoSuchSubUser):         ErrorClassNotFound,
+	string(admin.ErrNoSuchBucket):          ErrorClassNotFound,
+	string(admin.ErrNoSuchObject):          ErrorClassNotFound,
+	string(admin.ErrNoSuchKey):             ErrorClassNotFound,
+	string(admin.ErrNoSuchCap):             ErrorClassNotFound,
+	string(admin.ErrUserExists):            ErrorClassConflict,
+	string(admin.ErrKeyExists):             ErrorClassConflict,
+	string(admin.ErrEmailExists):           ErrorClassConflict,
+	string(admin.ErrSubuserExists):         ErrorClassConflict,
+	string(admin.ErrBucketNotEmpty):        ErrorClassConflict,
+	string(admin.ErrAccessDenied):          ErrorClassAuth,
+	string(admin.ErrInvalidAccessKey):      ErrorClassAuth,
+	string(admin.ErrSignatureDoesNotMatch): ErrorClassAuth,
+	string(admin.ErrInternalError):         ErrorClassTransient,
+
+	// S3
+	ErrCodeNotFound:                     ErrorClassNotFound,
+	ErrCodeNoSuchUpload:                 ErrorClassNotFound,
+	ErrCodeNoSuchBucketPolicy:           ErrorClassNotFound,
+	ErrCodeNoSuchLifecycleConfiguration: ErrorClassNotFound,
+	ErrCodeBucketAlreadyExists:          ErrorClassConflict,
+	ErrCodeBucketAlreadyOwnedByYou:      ErrorClassConflict,
+	"OperationAborted":                  ErrorClassConflict,
+	"InvalidAccessKeyId":                ErrorClassAuth,
+	"ExpiredToken":                      ErrorClassAuth,
+	"RequestTimeTooSkewed":              ErrorClassAuth,
+	"SlowDown":                          ErrorClassThrottled,
+	"ServiceUnavailable":                ErrorClassTransient,
+	"RequestTimeout":                    ErrorClassTransient,
+
+	// IAM and STS
+	iam.ErrCodeNoSuchEntityException:           ErrorClassNotFound,
+	iam.ErrCodeEntityAlreadyExistsException:    ErrorClassConflict,
+	iam.ErrCodeDeleteConflictException:         ErrorClassConflict,
+	iam.ErrCodeConcurrentModificationException: ErrorClassConflict,
+	iam.ErrCodeServiceFailureException:         ErrorClassTransient,
+	"InvalidClientTokenId":                     ErrorClassAuth,
+}
+
+// ErrorCode returns the error code reported by RGW, or "" when err does not
+// carry one.
+func ErrorCode(err error) string {
+	var adminError *RgwAdminError
+	if errors.As(err, &adminError) {
+		return adminError.Code
+	}
+
+	var awsError awserr.Error
+	if errors.As(err, &awsError) {
+		return awsError.Code()
+	}
+
+	var apiError smithy.APIError
+	if errors.As(err, &apiError) {
+		return apiError.ErrorCode()
+	}
+
+	// go-ceph does not export its status error, whose message starts with
+	// the code
+	if err != nil && reflect.TypeOf(err).PkgPath() == goCephAdminPackage {
+		code, _, _ := strings.Cut(err.Error(), " ")
+		return code
+	}
+
+	return ""
+}
+
+// ErrorStatusCode returns the HTTP status of the failed response, or 0 when
+// err does not carry one.
+func ErrorStatusCode(err error) int {
+	var adminError *RgwAdminError
+	if errors.As(err, &adminError) {
+		return adminError.StatusCode
+	}
+
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) {
+		return requestFailure.StatusCode()
+	}
+
+	var responseError interface{ HTTPStatusCode() int }
+	if errors.As(err, &responseError) {
+		return responseError.HTTPStatusCode()
+	}
+
+	return 0
+}
+
+// ClassifyError classifies an error of go-ceph's admin API, RgwAdminClient or
+// the AWS SDK.
+func ClassifyError(err error) ErrorClass {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return ErrorClassUnknown
+	}
+
+	if class, found := errorCodeClasses[ErrorCode(err)]; found {
+		return class
+	}
+
+	if request.IsErrorThrottle(err) {
+		return ErrorClassThrottled
+	}
+
+	if class := ClassifyHTTPStatus(ErrorStatusCode(err)); class != ErrorClassUnknown {
+		return class
+	}
+
+	// Certificate errors and unknown hosts do not go away by sending the
+	// request again
+	var dnsError *net.DNSError
+	if IsTLSError(err) || (findError(err, &dnsError) && dnsError.IsNotFound) {
+		return ErrorClassUnknown
+	}
+
+	// The SDK wraps connection failures in its own errors
+	var awsError awserr.Error
+	if errors.As(err, &awsError) && request.IsErrorRetryable(awsError) {
+		return ErrorClassTransient
+	}
+
+	var netError net.Error
+	if errors.As(err, &netError) {
+		return ErrorClassTransient
+	}
+
+	return ErrorClassUnknown
+}
+
+// ClassifyHTTPStatus classifies an error response by its status alone.
+func ClassifyHTTPStatus(status int) ErrorClass {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrorClassNotFound
+	case status == http.StatusConflict:
+		return ErrorClassConflict
+	case status == http.StatusTooManyRequests:
+		return ErrorClassThrottled
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrorClassAuth
+	case status >= http.StatusInternalServerError && status != http.StatusNotImplemented:
+		return ErrorClassTransient
+	default:
+		return ErrorClassUnknown
+	}
+}
+
+// IsDNSError reports whether a call failed because the host name of the
+// endpoint could not be resolved.
+func IsDNSError(err error) bool {
+	var dnsError *net.DNSError
+	return findError(err, &dnsError)
+}
+
+// IsTLSError reports whether a call failed because the TLS handshake with
+// the endpoint did not complete, usually because its certificate is not
+// trusted or the endpoint does not serve TLS.
+func IsTLSError(err error) bool {
+	var verificationError *tls.CertificateVerificationError
+	var recordHeaderError tls.RecordHeaderError
+	var unknownAuthorityError x509.UnknownAuthorityError
+	var hostnameError x509.HostnameError
+	var invalidError x509.CertificateInvalidError
+	return findError(err, &verificationError) ||
+		findError(err, &recordHeaderError) ||
+		findError(err, &unknownAuthorityError) ||
+		findError(err, &hostnameError) ||
+		findError(err, &invalidError)
+}
+
+// findError is errors.As, but also follows the original errors of the SDK,
+// which do not implement Unwrap.
+func findError[T error](err error, target *T) bool {
+	for err != nil {
+		if errors.As(err, target) {
+			return true
+		}
+
+		var awsError awserr.Error
+		if !errors.As(err, &awsError) {
+			return false
+		}
+		err = awsError.OrigErr()
+	}
+	return false
+}
+
+// IsNotFound reports whether a call failed because the entity does not exist.
+func IsNotFound(err error) bool {
+	return ClassifyError(err) == ErrorClassNotFound
+}
+
+// IsRetryable reports whether a failed call may succeed when sent again.
+func IsRetryable(err error) bool {
+	class := ClassifyError(err)
+	return class == ErrorClassThrottled || class == ErrorClassTransient
+}
+
+// HasErrorCode reports whether RGW failed the call with one of codes.
+func HasErrorCode(err error, codes ...string) bool {
+	code := ErrorCode(err)
+	for _, candidate := range codes {
+		if code != "" && code == candidate {
+			return true
+		}
+	}
+	return false
+}
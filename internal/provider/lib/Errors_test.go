@@ -0,0 +1,101 @@
+package lib
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/smithy-go"
+	"github.com/ceph/go-ceph/rgw/admin"
+)
+
+func TestClassifyError(t *testing.T) {
+	connectionRefused := &url.Error{Op: "Get", URL: "http://rgw:8080/admin/user", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+
+	tests := []struct {
+		name      string
+		err       error
+		class     ErrorClass
+		retryable bool
+	}{
+		{name: "no error", err: nil, class: ErrorClassUnknown},
+		{name: "canceled", err: fmt.Errorf("get user: %w", context.Canceled), class: ErrorClassUnknown},
+		{name: "admin no such user", err: &RgwAdminError{StatusCode: http.StatusNotFound, Code: string(admin.ErrNoSuchUser)}, class: ErrorClassNotFound},
+		{name: "admin user exists", err: &RgwAdminError{StatusCode: http.StatusConflict, Code: string(admin.ErrUserExists)}, class: ErrorClassConflict},
+		{name: "admin access denied", err: &RgwAdminError{StatusCode: http.StatusForbidden, Code: string(admin.ErrAccessDenied)}, class: ErrorClassAuth},
+		{name: "admin internal error", err: &RgwAdminError{StatusCode: http.StatusInternalServerError, Code: string(admin.ErrInternalError)}, class: ErrorClassTransient, retryable: true},
+		{name: "admin unknown code", err: &RgwAdminError{StatusCode: http.StatusServiceUnavailable, Code: "Unexpected"}, class: ErrorClassTransient, retryable: true},
+		{name: "admin not implemented", err: &RgwAdminError{StatusCode: http.StatusNotImplemented, Code: "NotImplemented"}, class: ErrorClassUnknown},
+		{name: "s3 slow down", err: &smithy.GenericAPIError{Code: "SlowDown"}, class: ErrorClassThrottled, retryable: true},
+		{name: "s3 no such bucket policy", err: &smithy.GenericAPIError{Code: ErrCodeNoSuchBucketPolicy}, class: ErrorClassNotFound},
+		{name: "iam no such entity", err: awserr.NewRequestFailure(awserr.New("NoSuchEntity", "not found", nil), http.StatusNotFound, ""), class: ErrorClassNotFound},
+		{name: "iam throttling", err: awserr.NewRequestFailure(awserr.New("Throttling", "slow down", nil), http.StatusBadRequest, ""), class: ErrorClassThrottled, retryable: true},
+		{name: "iam server failure status", err: awserr.NewRequestFailure(awserr.New("Unexpected", "", nil), http.StatusBadGateway, ""), class: ErrorClassTransient, retryable: true},
+		{name: "connection refused", err: connectionRefused, class: ErrorClassTransient, retryable: true},
+		{name: "sdk connection refused", err: awserr.New("RequestError", "send request failed", connectionRefused), class: ErrorClassTransient, retryable: true},
+		{name: "unknown host", err: &url.Error{Op: "Get", URL: "http://nowhere", Err: &net.DNSError{Name: "nowhere", IsNotFound: true}}, class: ErrorClassUnknown},
+		{name: "untrusted certificate", err: &url.Error{Op: "Get", URL: "https://rgw", Err: x509.UnknownAuthorityError{}}, class: ErrorClassUnknown},
+		{name: "plain error", err: errors.New("boom"), class: ErrorClassUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if class := ClassifyError(test.err); class != test.class {
+				t.Errorf("expected class %s, got %s", test.class, class)
+			}
+			if retryable := IsRetryable(test.err); retryable != test.retryable {
+				t.Errorf("expected retryable %t, got %t", test.retryable, retryable)
+			}
+		})
+	}
+}
+
+func TestClassifyGoCephError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"Code":"NoSuchBucket","RequestId":"tx1","HostId":"default"}`))
+	}))
+	defer server.Close()
+
+	rgw, err := admin.New(server.URL, "ACCESSKEY", "secret", http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = rgw.GetBucketInfo(context.Background(), admin.Bucket{Bucket: "missing"})
+	if code := ErrorCode(err); code != string(admin.ErrNoSuchBucket) {
+		t.Errorf("expected code %s, got %q", admin.ErrNoSuchBucket, code)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("expected %v to be not found", err)
+	}
+}
+
+func TestClassifyHTTPStatus(t *testing.T) {
+	tests := map[int]ErrorClass{
+		http.StatusOK:                  ErrorClassUnknown,
+		http.StatusBadRequest:          ErrorClassUnknown,
+		http.StatusUnauthorized:        ErrorClassAuth,
+		http.StatusForbidden:           ErrorClassAuth,
+		http.StatusNotFound:            ErrorClassNotFound,
+		http.StatusConflict:            ErrorClassConflict,
+		http.StatusTooManyRequests:     ErrorClassThrottled,
+		http.StatusInternalServerError: ErrorClassTransient,
+		http.StatusNotImplemented:      ErrorClassUnknown,
+		http.StatusServiceUnavailable:  ErrorClassTransient,
+	}
+
+	for status, expected := range tests {
+		if class := ClassifyHTTPStatus(status); class != expected {
+			t.Errorf("expected HTTP %d to be %s, got %s", status, expected, class)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// DecodeIamPolicyDocument returns the JSON of a policy document as returned by
+// the IAM API, which AWS URL-encodes while RGW does not.
+func DecodeIamPolicyDocument(document string) string {
+	if strings.HasPrefix(strings.TrimSpace(document), "{") {
+		return document
+	}
+
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return document
+	}
+	return decoded
+}
+
+// IamPolicyDocumentsEquivalent reports whether both policy documents hold the
+// same JSON, regardless of formatting and key order.
+func IamPolicyDocumentsEquivalent(a, b string) bool {
+	var decodedA, decodedB interface{}
+	if json.Unmarshal([]byte(a), &decodedA) != nil || json.Unmarshal([]byte(b), &decodedB) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(decodedA, decodedB)
+}
@@ -0,0 +1,147 @@
+package lib
+
+import (
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/ceph/go-ceph/rgw/admin"
+)
+
+const (
+	DefaultMaxRetries      = 5
+	DefaultRetryMaxBackoff = 30 * time.Second
+
+	retryMinBackoff = 200 * time.Millisecond
+)
+
+// RetryBackoff returns how long to wait before sending a request again after
+// attempt failed ones. The delay grows exponentially up to maxBackoff, with
+// jitter so that concurrent requests do not retry in lockstep.
+func RetryBackoff(attempt int, maxBackoff time.Duration) time.Duration {
+	backoff := maxBackoff
+	if attempt < 30 && retryMinBackoff<<attempt < maxBackoff {
+		backoff = retryMinBackoff << attempt
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// RgwRetryer retries the IAM and STS requests that fail with a throttled or
+// transient error. As for admin requests, operations that change something
+// are only sent again when throttled or when RGW cannot have acted on them.
+type RgwRetryer struct {
+	NumMaxRetries int
+	MaxBackoff    time.Duration
+}
+
+var _ request.Retryer = RgwRetryer{}
+
+func (r RgwRetryer) MaxRetries() int {
+	return r.NumMaxRetries
+}
+
+func (r RgwRetryer) RetryRules(req *request.Request) time.Duration {
+	return RetryBackoff(req.RetryCount, r.MaxBackoff)
+}
+
+func (r RgwRetryer) ShouldRetry(req *request.Request) bool {
+	if req.Retryable != nil {
+		return *req.Retryable
+	}
+	if !IsRetryable(req.Error) {
+		return false
+	}
+	return (req.Operation != nil && IsIdempotentOperation(req.Operation.Name)) ||
+		ClassifyError(req.Error) == ErrorClassThrottled ||
+		!IsRequestSent(req.Error)
+}
+
+// RgwRetryingHTTPClient retries the admin requests that fail with a
+// throttled or transient error. It must wrap the client signing the requests,
+// so that every attempt is signed again.
+//
+// Admin writes are not idempotent, creating a key or a bucket twice does not
+// give the same result, so requests other than reads are only sent again
+// when throttled or when RGW cannot have acted on them.
+type RgwRetryingHTTPClient struct {
+	HTTPClient admin.HTTPClient
+	MaxRetries int
+	MaxBackoff time.Duration
+}
+
+func (c *RgwRetryingHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+
+		response, err := c.HTTPClient.Do(request)
+
+		retry := false
+		if err != nil {
+			retry = IsRetryable(err) && (IsIdempotentMethod(request.Method) || !IsRequestSent(err))
+		} else {
+			class := ClassifyHTTPStatus(response.StatusCode)
+			retry = class == ErrorClassThrottled || (class == ErrorClassTransient && IsIdempotentMethod(request.Method))
+		}
+
+		if !retry || attempt >= c.MaxRetries {
+			return response, err
+		}
+
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(RetryBackoff(attempt, c.MaxBackoff)):
+		}
+	}
+}
+
+// IsIdempotentMethod reports whether requests with method can be sent again
+// without changing the result. PUT and DELETE are not considered idempotent,
+// as RGW admin PUT requests create keys, users and buckets.
+func IsIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsIdempotentOperation reports whether the IAM or STS operation name can be
+// sent again without changing the result. Every IAM and STS request is a
+// POST, so the method cannot tell. Assuming a role again only issues other
+// temporary credentials.
+func IsIdempotentOperation(name string) bool {
+	for _, prefix := range []string{"Get", "List", "Assume"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRequestSent reports whether the request that failed with err may have
+// reached the gateway. Only failures to connect prove it did not.
+func IsRequestSent(err error) bool {
+	var opError *net.OpError
+	return !findError(err, &opError) || opError.Op != "dial"
+}
@@ -0,0 +1,154 @@
+package lib
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+func TestRgwRetryingHTTPClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		attempts int32
+	}{
+		{name: "read on server error", method: http.MethodGet, status: http.StatusInternalServerError, attempts: 3},
+		{name: "read on throttling", method: http.MethodGet, status: http.StatusTooManyRequests, attempts: 3},
+		{name: "read on not found", method: http.MethodGet, status: http.StatusNotFound, attempts: 1},
+		{name: "write on server error", method: http.MethodPut, status: http.StatusInternalServerError, attempts: 1},
+		{name: "write on bad gateway", method: http.MethodPost, status: http.StatusBadGateway, attempts: 1},
+		{name: "write on throttling", method: http.MethodPost, status: http.StatusTooManyRequests, attempts: 3},
+		{name: "write on success", method: http.MethodPut, status: http.StatusOK, attempts: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			client := &RgwRetryingHTTPClient{
+				HTTPClient: http.DefaultClient,
+				MaxRetries: 2,
+				MaxBackoff: time.Millisecond,
+			}
+
+			request, err := http.NewRequest(test.method, server.URL+"/admin/user", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := client.Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response.Body.Close()
+
+			if response.StatusCode != test.status {
+				t.Errorf("expected HTTP %d, got %d", test.status, response.StatusCode)
+			}
+			if attempts.Load() != test.attempts {
+				t.Errorf("expected %d attempts, got %d", test.attempts, attempts.Load())
+			}
+		})
+	}
+}
+
+func TestRgwRetryingHTTPClientConnectionRefused(t *testing.T) {
+	// Reserve a port, then close it so that connecting is refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	var attempts atomic.Int32
+	client := &RgwRetryingHTTPClient{
+		HTTPClient: countingHTTPClient{HTTPClient: http.DefaultClient, attempts: &attempts},
+		MaxRetries: 2,
+		MaxBackoff: time.Millisecond,
+	}
+
+	// A write that never reached the gateway is safe to send again
+	request, err := http.NewRequest(http.MethodPut, "http://"+address+"/admin/user?key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(request); err == nil {
+		t.Fatal("expected a connection error")
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRgwRetryerShouldRetry(t *testing.T) {
+	serverError := awserr.NewRequestFailure(awserr.New("InternalError", "", nil), http.StatusInternalServerError, "")
+	throttled := awserr.NewRequestFailure(awserr.New("Throttling", "", nil), http.StatusServiceUnavailable, "")
+	refused := awserr.New(request.ErrCodeRequestError, "send request failed", &url.Error{Op: "Post", URL: "http://rgw", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}})
+	reset := awserr.New(request.ErrCodeRequestError, "send request failed", &url.Error{Op: "Post", URL: "http://rgw", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}})
+
+	tests := []struct {
+		name      string
+		operation string
+		err       error
+		retryable *bool
+		expected  bool
+	}{
+		{name: "read on server error", operation: "GetUser", err: serverError, expected: true},
+		{name: "list on connection reset", operation: "ListAccessKeys", err: reset, expected: true},
+		{name: "assume role on server error", operation: "AssumeRole", err: serverError, expected: true},
+		{name: "create on server error", operation: "CreateAccessKey", err: serverError, expected: false},
+		{name: "create on connection reset", operation: "CreateRole", err: reset, expected: false},
+		{name: "create on connection refused", operation: "CreateUser", err: refused, expected: true},
+		{name: "create on throttling", operation: "CreateUser", err: throttled, expected: true},
+		{name: "read not retryable", operation: "GetUser", err: serverError, retryable: aws.Bool(false), expected: false},
+	}
+
+	retryer := RgwRetryer{NumMaxRetries: 2, MaxBackoff: time.Millisecond}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &request.Request{
+				Operation: &request.Operation{Name: test.operation},
+				Error:     test.err,
+				Retryable: test.retryable,
+			}
+			if actual := retryer.ShouldRetry(req); actual != test.expected {
+				t.Errorf("expected retry %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsRequestSent(t *testing.T) {
+	if IsRequestSent(&net.OpError{Op: "dial", Err: &net.AddrError{Err: "refused"}}) {
+		t.Error("expected a dial error to tell the request was not sent")
+	}
+	if !IsRequestSent(&net.OpError{Op: "read", Err: &net.AddrError{Err: "reset"}}) {
+		t.Error("expected a read error to tell the request may have been sent")
+	}
+}
+
+type countingHTTPClient struct {
+	HTTPClient *http.Client
+	attempts   *atomic.Int32
+}
+
+func (c countingHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	c.attempts.Add(1)
+	return c.HTTPClient.Do(request)
+}
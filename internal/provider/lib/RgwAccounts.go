@@ -0,0 +1,130 @@
+package lib
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+)
+
+// RgwAccount is an RGW user account, available since Squid.
+type RgwAccount struct {
+	Id            string          `json:"id"`
+	Tenant        string          `json:"tenant"`
+	Name          string          `json:"name"`
+	Email         string          `json:"email"`
+	Quota         admin.QuotaSpec `json:"quota"`
+	BucketQuota   admin.QuotaSpec `json:"bucket_quota"`
+	MaxUsers      int64           `json:"max_users"`
+	MaxRoles      int64           `json:"max_roles"`
+	MaxGroups     int64           `json:"max_groups"`
+	MaxBuckets    int64           `json:"max_buckets"`
+	MaxAccessKeys int64           `json:"max_access_keys"`
+}
+
+// RgwAccountSpec holds the account settings to create or modify. Nil limits
+// are left to their defaults or current values.
+type RgwAccountSpec struct {
+	Id         string
+	Name       string
+	Email      string
+	MaxUsers   *int64
+	MaxRoles   *int64
+	MaxGroups  *int64
+	MaxBuckets *int64
+}
+
+// RgwAccountQuota is the quota applied to the sum of the buckets of an
+// account. -1 means unlimited.
+type RgwAccountQuota struct {
+	Enabled    bool
+	MaxSize    int64
+	MaxObjects int64
+}
+
+// RgwUserAccount tells which account a user belongs to.
+type RgwUserAccount struct {
+	AccountId string `json:"account_id"`
+	Type      string `json:"type"`
+}
+
+// IsRoot reports whether the user is the root user of its account.
+func (u RgwUserAccount) IsRoot() bool {
+	return u.Type == "root"
+}
+
+func (s RgwAccountSpec) values() url.Values {
+	args := url.Values{}
+	if s.Id != "" {
+		args.Set("id", s.Id)
+	}
+	args.Set("name", s.Name)
+	args.Set("email", s.Email)
+
+	for name, value := range map[string]*int64{
+		"max-users":   s.MaxUsers,
+		"max-roles":   s.MaxRoles,
+		"max-groups":  s.MaxGroups,
+		"max-buckets": s.MaxBuckets,
+	} {
+		if value != nil {
+			args.Set(name, strconv.FormatInt(*value, 10))
+		}
+	}
+
+	return args
+}
+
+// CreateAccount creates an account. RGW generates the id when spec has none.
+func (c *RgwAdminClient) CreateAccount(ctx context.Context, spec RgwAccountSpec) (RgwAccount, error) {
+	var account RgwAccount
+	err := c.Call(ctx, http.MethodPost, "/account", spec.values(), nil, &account)
+	return account, err
+}
+
+func (c *RgwAdminClient) GetAccount(ctx context.Context, id string) (RgwAccount, error) {
+	var account RgwAccount
+	err := c.Call(ctx, http.MethodGet, "/account", url.Values{"id": {id}}, nil, &account)
+	return account, err
+}
+
+func (c *RgwAdminClient) ModifyAccount(ctx context.Context, spec RgwAccountSpec) (RgwAccount, error) {
+	var account RgwAccount
+	err := c.Call(ctx, http.MethodPut, "/account", spec.values(), nil, &account)
+	return account, err
+}
+
+func (c *RgwAdminClient) DeleteAccount(ctx context.Context, id string) error {
+	return c.Call(ctx, http.MethodDelete, "/account", url.Values{"id": {id}}, nil, nil)
+}
+
+// SetAccountQuota sets the quota of an account, the way
+// `radosgw-admin quota set --account-id` does.
+func (c *RgwAdminClient) SetAccountQuota(ctx context.Context, id string, quota RgwAccountQuota) error {
+	return c.Call(ctx, http.MethodPut, "/account?quota", url.Values{
+		"id":          {id},
+		"quota-type":  {"account"},
+		"enabled":     {strconv.FormatBool(quota.Enabled)},
+		"max-size":    {strconv.FormatInt(quota.MaxSize, 10)},
+		"max-objects": {strconv.FormatInt(quota.MaxObjects, 10)},
+	}, nil, nil)
+}
+
+// GetUserAccount returns the account membership of a user, which go-ceph's
+// admin.User does not decode.
+func (c *RgwAdminClient) GetUserAccount(ctx context.Context, uid string) (RgwUserAccount, error) {
+	var account RgwUserAccount
+	err := c.Call(ctx, http.MethodGet, "/user", url.Values{"uid": {uid}}, nil, &account)
+	return account, err
+}
+
+// SetUserAccount moves a user into an account, optionally as its root user.
+func (c *RgwAdminClient) SetUserAccount(ctx context.Context, uid string, accountId string, root bool) error {
+	return c.Call(ctx, http.MethodPost, "/user", url.Values{
+		"uid":          {uid},
+		"account-id":   {accountId},
+		"account-root": {strconv.FormatBool(root)},
+	}, nil, nil)
+}
@@ -0,0 +1,109 @@
+package lib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+)
+
+const rgwAdminPath = "/admin"
+
+// RgwAdminClient calls the RGW Admin Ops API endpoints that are not covered
+// by go-ceph's admin.API. Requests are signed by the HTTP client, such as an
+// RgwSigningHTTPClient.
+type RgwAdminClient struct {
+	Endpoint   string
+	HTTPClient admin.HTTPClient
+}
+
+// RgwAdminError is returned when RGW answers an admin request with an error
+// status.
+type RgwAdminError struct {
+	StatusCode int
+	Code       string `json:"Code"`
+	RequestID  string `json:"RequestId"`
+}
+
+func (e *RgwAdminError) Error() string {
+	return fmt.Sprintf("%s %s (HTTP %d)", e.Code, e.RequestID, e.StatusCode)
+}
+
+func NewRgwAdminClient(endpoint string, httpClient admin.HTTPClient) *RgwAdminClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &RgwAdminClient{
+		Endpoint:   strings.TrimSuffix(endpoint, "/"),
+		HTTPClient: httpClient,
+	}
+}
+
+// Call sends a request to the admin API and decodes the JSON response
+// into out, when out is not nil. The path is relative to the admin entry
+// point and may carry a bare sub-resource marker, such as "/account?quota".
+func (c *RgwAdminClient) Call(ctx context.Context, method, path string, args url.Values, body interface{}, out interface{}) error {
+	if args == nil {
+		args = url.Values{}
+	}
+	args.Set("format", "json")
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	var payload []byte
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = encoded
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.Endpoint+rgwAdminPath+path+separator+args.Encode(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	decoded, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode >= 300 {
+		adminError := &RgwAdminError{StatusCode: response.StatusCode}
+		if json.Unmarshal(decoded, adminError) != nil || adminError.Code == "" {
+			adminError.Code = http.StatusText(response.StatusCode)
+		}
+		return adminError
+	}
+
+	if out == nil || len(decoded) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(decoded, out); err != nil {
+		return fmt.Errorf("failed to unmarshal radosgw http response. %s. %w", string(decoded), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	RgwReshardStatusNotResharding = "not-resharding"
+	RgwReshardStatusInProgress    = "in-progress"
+	RgwReshardStatusDone          = "done"
+)
+
+// rgwReshardStatuses maps the cls_rgw_reshard_status values stored in the
+// bucket instance metadata.
+var rgwReshardStatuses = []string{
+	RgwReshardStatusNotResharding,
+	RgwReshardStatusInProgress,
+	RgwReshardStatusDone,
+}
+
+type rgwBucketInstanceMetadata struct {
+	Data struct {
+		BucketInfo struct {
+			ReshardStatus int `json:"reshard_status"`
+		} `json:"bucket_info"`
+	} `json:"data"`
+}
+
+// GetBucketReshardStatus reads the reshard status of a bucket instance from
+// its metadata.
+func (c *RgwAdminClient) GetBucketReshardStatus(ctx context.Context, bucket string, bucketId string) (string, error) {
+	var metadata rgwBucketInstanceMetadata
+	err := c.Call(ctx, http.MethodGet, "/metadata/bucket.instance", url.Values{"key": {bucket + ":" + bucketId}}, nil, &metadata)
+	if err != nil {
+		return "", err
+	}
+
+	status := metadata.Data.BucketInfo.ReshardStatus
+	if status < 0 || status >= len(rgwReshardStatuses) {
+		return "", fmt.Errorf("unknown reshard status %d of bucket %s", status, bucket)
+	}
+	return rgwReshardStatuses[status], nil
+}
@@ -0,0 +1,236 @@
+package lib
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+// The environment variables the access_key and secret_key of the provider
+// configuration fall back to.
+const (
+	RgwAccessKeyVariable = "CEPH_RGW_ACCESS_KEY"
+	RgwSecretKeyVariable = "CEPH_RGW_SECRET_KEY"
+)
+
+// ErrRgwCredentialSourceNotSet is returned by the credential sources that are
+// not configured, so that the next source is tried.
+var ErrRgwCredentialSourceNotSet = errors.New("not set")
+
+// RgwCredentialSource is one of the places the provider reads its access and
+// secret keys from.
+type RgwCredentialSource struct {
+	Name     string
+	Retrieve func() (credentials.Value, error)
+}
+
+// ResolveRgwCredentials returns the credentials of the first configured
+// source, along with its name. A source that is configured but cannot be read
+// stops the resolution, rather than silently falling back to the next one.
+func ResolveRgwCredentials(sources []RgwCredentialSource) (credentials.Value, string, error) {
+	var tried []string
+	for _, source := range sources {
+		value, err := source.Retrieve()
+		if errors.Is(err, ErrRgwCredentialSourceNotSet) {
+			tried = append(tried, source.Name)
+			continue
+		}
+		if err != nil {
+			return credentials.Value{}, source.Name, fmt.Errorf("%s: %w", source.Name, err)
+		}
+		return value, source.Name, nil
+	}
+
+	return credentials.Value{}, "", fmt.Errorf("credentials %w, tried: %s", ErrRgwCredentialSourceNotSet, strings.Join(tried, "; "))
+}
+
+// RgwStaticCredentialSource reads a pair of keys, which must be set together.
+func RgwStaticCredentialSource(name, accessKey, secretKey string) RgwCredentialSource {
+	return RgwCredentialSource{
+		Name: name,
+		Retrieve: func() (credentials.Value, error) {
+			return staticCredentials(accessKey, secretKey)
+		},
+	}
+}
+
+// RgwConfigCredentialSource reads the access_key and secret_key of the
+// provider configuration, each falling back to its environment variable when
+// unset, so that the secret key can for instance be kept out of the
+// configuration. The source is named after where the keys were found.
+func RgwConfigCredentialSource(accessKey, secretKey string) RgwCredentialSource {
+	envAccessKey := os.Getenv(RgwAccessKeyVariable)
+	envSecretKey := os.Getenv(RgwSecretKeyVariable)
+
+	name := "access_key and secret_key"
+	switch {
+	case accessKey == "" && secretKey == "" && envAccessKey == "" && envSecretKey == "":
+		name = "access_key and secret_key or " + RgwAccessKeyVariable + " and " + RgwSecretKeyVariable + " environment variables"
+	case accessKey == "" && secretKey == "":
+		name = RgwAccessKeyVariable + " and " + RgwSecretKeyVariable + " environment variables"
+	case accessKey == "" && envAccessKey != "":
+		name = RgwAccessKeyVariable + " environment variable and secret_key"
+	case secretKey == "" && envSecretKey != "":
+		name = "access_key and " + RgwSecretKeyVariable + " environment variable"
+	}
+
+	if accessKey == "" {
+		accessKey = envAccessKey
+	}
+	if secretKey == "" {
+		secretKey = envSecretKey
+	}
+	return RgwStaticCredentialSource(name, accessKey, secretKey)
+}
+
+// RgwCredentialsFileSource reads the keys from a file holding a single
+// identity, such as those rendered by Vault. The file is either JSON, with
+// access_key and secret_key, the output of `radosgw-admin user info`, or INI,
+// with access_key and secret_key or their aws_ equivalents.
+func RgwCredentialsFileSource(name, path string) RgwCredentialSource {
+	return RgwCredentialSource{
+		Name: name,
+		Retrieve: func() (credentials.Value, error) {
+			if path == "" {
+				return credentials.Value{}, ErrRgwCredentialSourceNotSet
+			}
+
+			content, err := os.ReadFile(expandHome(path))
+			if err != nil {
+				return credentials.Value{}, err
+			}
+
+			if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+				return parseJSONCredentials(trimmed)
+			}
+			return parseINICredentials(content)
+		},
+	}
+}
+
+// RgwSharedCredentialSource reads the keys of a profile from the first
+// shared credentials file that has it, like the AWS CLI does. The profile
+// defaults to "default" and the files to ~/.aws/credentials, but the source
+// is only tried when either is set.
+func RgwSharedCredentialSource(name string, files []string, profile string) RgwCredentialSource {
+	return RgwCredentialSource{
+		Name: name,
+		Retrieve: func() (credentials.Value, error) {
+			if len(files) == 0 && profile == "" {
+				return credentials.Value{}, ErrRgwCredentialSourceNotSet
+			}
+			if profile == "" {
+				profile = "default"
+			}
+			if len(files) == 0 {
+				files = []string{""}
+			}
+
+			var errs []error
+			for _, file := range files {
+				provider := &credentials.SharedCredentialsProvider{Filename: expandHome(file), Profile: profile}
+				value, err := provider.Retrieve()
+				if err == nil {
+					return value, nil
+				}
+				if file == "" {
+					file = "default shared credentials file"
+				}
+				errs = append(errs, fmt.Errorf("profile %s in %s: %w", profile, file, err))
+			}
+			return credentials.Value{}, errors.Join(errs...)
+		},
+	}
+}
+
+func expandHome(path string) string {
+	if rest, found := strings.CutPrefix(path, "~/"); found {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+func staticCredentials(accessKey, secretKey string) (credentials.Value, error) {
+	switch {
+	case accessKey == "" && secretKey == "":
+		return credentials.Value{}, ErrRgwCredentialSourceNotSet
+	case accessKey == "":
+		return credentials.Value{}, errors.New("the secret key is set without an access key")
+	case secretKey == "":
+		return credentials.Value{}, errors.New("the access key is set without a secret key")
+	}
+
+	return credentials.Value{
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+	}, nil
+}
+
+type rgwCredentialsFile struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	Keys      []struct {
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+	} `json:"keys"`
+}
+
+func parseJSONCredentials(content []byte) (credentials.Value, error) {
+	var file rgwCredentialsFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return credentials.Value{}, err
+	}
+
+	if file.AccessKey == "" && file.SecretKey == "" && len(file.Keys) > 0 {
+		return missingKeysError(staticCredentials(file.Keys[0].AccessKey, file.Keys[0].SecretKey))
+	}
+	return missingKeysError(staticCredentials(file.AccessKey, file.SecretKey))
+}
+
+func parseINICredentials(content []byte) (credentials.Value, error) {
+	var accessKey, secretKey string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		switch strings.TrimSpace(key) {
+		case "access_key", "aws_access_key_id":
+			accessKey = value
+		case "secret_key", "aws_secret_access_key":
+			secretKey = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return credentials.Value{}, err
+	}
+
+	return missingKeysError(staticCredentials(accessKey, secretKey))
+}
+
+// missingKeysError reports a configured file without keys as an error, as
+// the file is not a source that can be skipped.
+func missingKeysError(value credentials.Value, err error) (credentials.Value, error) {
+	if errors.Is(err, ErrRgwCredentialSourceNotSet) {
+		return value, errors.New("no access_key and secret_key found")
+	}
+	return value, err
+}
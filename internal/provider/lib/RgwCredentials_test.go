@@ -0,0 +1,258 @@
+package lib
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeCredentialsFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestResolveRgwCredentials(t *testing.T) {
+	sharedFile := writeCredentialsFile(t, "credentials", "[default]\naws_access_key_id = DEFAULTKEY\naws_secret_access_key = defaultsecret\n\n[ci]\naws_access_key_id = CIKEY\naws_secret_access_key = cisecret\n")
+
+	tests := []struct {
+		name           string
+		sources        []RgwCredentialSource
+		expectedKey    string
+		expectedSource string
+		expectedError  string
+	}{
+		{
+			name: "first set source wins",
+			sources: []RgwCredentialSource{
+				RgwStaticCredentialSource("configuration", "CONFIGKEY", "configsecret"),
+				RgwStaticCredentialSource("environment", "ENVKEY", "envsecret"),
+			},
+			expectedKey:    "CONFIGKEY",
+			expectedSource: "configuration",
+		},
+		{
+			name: "unset sources are skipped",
+			sources: []RgwCredentialSource{
+				RgwStaticCredentialSource("configuration", "", ""),
+				RgwCredentialsFileSource("credentials_file", ""),
+				RgwStaticCredentialSource("environment", "ENVKEY", "envsecret"),
+			},
+			expectedKey:    "ENVKEY",
+			expectedSource: "environment",
+		},
+		{
+			name: "half set keys stop the resolution",
+			sources: []RgwCredentialSource{
+				RgwStaticCredentialSource("configuration", "CONFIGKEY", ""),
+				RgwStaticCredentialSource("environment", "ENVKEY", "envsecret"),
+			},
+			expectedSource: "configuration",
+			expectedError:  "configuration: the access key is set without a secret key",
+		},
+		{
+			name: "unreadable file stops the resolution",
+			sources: []RgwCredentialSource{
+				RgwCredentialsFileSource("credentials_file", filepath.Join(t.TempDir(), "missing.json")),
+				RgwStaticCredentialSource("environment", "ENVKEY", "envsecret"),
+			},
+			expectedSource: "credentials_file",
+			expectedError:  "no such file or directory",
+		},
+		{
+			name: "json file",
+			sources: []RgwCredentialSource{
+				RgwCredentialsFileSource("credentials_file", writeCredentialsFile(t, "keys.json", `{"access_key": "JSONKEY", "secret_key": "jsonsecret"}`)),
+			},
+			expectedKey:    "JSONKEY",
+			expectedSource: "credentials_file",
+		},
+		{
+			name: "radosgw-admin user info",
+			sources: []RgwCredentialSource{
+				RgwCredentialsFileSource("credentials_file", writeCredentialsFile(t, "user.json", `{"user_id": "terraform", "keys": [{"user": "terraform", "access_key": "USERKEY", "secret_key": "usersecret"}]}`)),
+			},
+			expectedKey:    "USERKEY",
+			expectedSource: "credentials_file",
+		},
+		{
+			name: "ini file",
+			sources: []RgwCredentialSource{
+				RgwCredentialsFileSource("credentials_file", writeCredentialsFile(t, "keys.ini", "# rendered by Vault\naccess_key = \"INIKEY\"\nsecret_key = inisecret\n")),
+			},
+			expectedKey:    "INIKEY",
+			expectedSource: "credentials_file",
+		},
+		{
+			name: "file without keys",
+			sources: []RgwCredentialSource{
+				RgwCredentialsFileSource("credentials_file", writeCredentialsFile(t, "empty.json", `{"user_id": "terraform"}`)),
+			},
+			expectedSource: "credentials_file",
+			expectedError:  "no access_key and secret_key found",
+		},
+		{
+			name: "shared credentials profile",
+			sources: []RgwCredentialSource{
+				RgwSharedCredentialSource("profile", []string{sharedFile}, "ci"),
+			},
+			expectedKey:    "CIKEY",
+			expectedSource: "profile",
+		},
+		{
+			name: "shared credentials default profile",
+			sources: []RgwCredentialSource{
+				RgwSharedCredentialSource("profile", []string{sharedFile}, ""),
+			},
+			expectedKey:    "DEFAULTKEY",
+			expectedSource: "profile",
+		},
+		{
+			name: "shared credentials missing profile",
+			sources: []RgwCredentialSource{
+				RgwSharedCredentialSource("profile", []string{sharedFile}, "missing"),
+			},
+			expectedSource: "profile",
+			expectedError:  "profile missing in " + sharedFile,
+		},
+		{
+			name: "shared credentials not set",
+			sources: []RgwCredentialSource{
+				RgwSharedCredentialSource("profile", nil, ""),
+				RgwStaticCredentialSource("environment", "ENVKEY", "envsecret"),
+			},
+			expectedKey:    "ENVKEY",
+			expectedSource: "environment",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, source, err := ResolveRgwCredentials(test.sources)
+
+			if test.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+					t.Fatalf("expected an error containing %q, got %v", test.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if value.AccessKeyID != test.expectedKey {
+				t.Errorf("expected access key %q, got %q", test.expectedKey, value.AccessKeyID)
+			}
+			if source != test.expectedSource {
+				t.Errorf("expected source %q, got %q", test.expectedSource, source)
+			}
+		})
+	}
+}
+
+func TestResolveRgwCredentialsNotSet(t *testing.T) {
+	_, source, err := ResolveRgwCredentials([]RgwCredentialSource{
+		RgwStaticCredentialSource("configuration", "", ""),
+		RgwCredentialsFileSource("credentials_file", ""),
+	})
+
+	if !errors.Is(err, ErrRgwCredentialSourceNotSet) {
+		t.Fatalf("expected %v, got %v", ErrRgwCredentialSourceNotSet, err)
+	}
+	if !strings.Contains(err.Error(), "tried: configuration; credentials_file") {
+		t.Errorf("expected the tried sources in %q", err.Error())
+	}
+	if source != "" {
+		t.Errorf("expected no source, got %q", source)
+	}
+}
+
+func TestRgwConfigCredentialSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		accessKey      string
+		secretKey      string
+		envAccessKey   string
+		envSecretKey   string
+		expectedKey    string
+		expectedSecret string
+		expectedSource string
+		expectedError  string
+	}{
+		{
+			name:           "configuration",
+			accessKey:      "CONFIGKEY",
+			secretKey:      "configsecret",
+			envAccessKey:   "ENVKEY",
+			envSecretKey:   "envsecret",
+			expectedKey:    "CONFIGKEY",
+			expectedSecret: "configsecret",
+			expectedSource: "access_key and secret_key",
+		},
+		{
+			name:           "environment",
+			envAccessKey:   "ENVKEY",
+			envSecretKey:   "envsecret",
+			expectedKey:    "ENVKEY",
+			expectedSecret: "envsecret",
+			expectedSource: "CEPH_RGW_ACCESS_KEY and CEPH_RGW_SECRET_KEY environment variables",
+		},
+		{
+			name:           "secret key from the environment",
+			accessKey:      "CONFIGKEY",
+			envSecretKey:   "envsecret",
+			expectedKey:    "CONFIGKEY",
+			expectedSecret: "envsecret",
+			expectedSource: "access_key and CEPH_RGW_SECRET_KEY environment variable",
+		},
+		{
+			name:           "access key from the environment",
+			secretKey:      "configsecret",
+			envAccessKey:   "ENVKEY",
+			expectedKey:    "ENVKEY",
+			expectedSecret: "configsecret",
+			expectedSource: "CEPH_RGW_ACCESS_KEY environment variable and secret_key",
+		},
+		{
+			name:           "secret key missing",
+			accessKey:      "CONFIGKEY",
+			expectedSource: "access_key and secret_key",
+			expectedError:  "the access key is set without a secret key",
+		},
+		{
+			name:           "not set",
+			expectedSource: "access_key and secret_key or CEPH_RGW_ACCESS_KEY and CEPH_RGW_SECRET_KEY environment variables",
+			expectedError:  "not set",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(RgwAccessKeyVariable, test.envAccessKey)
+			t.Setenv(RgwSecretKeyVariable, test.envSecretKey)
+
+			source := RgwConfigCredentialSource(test.accessKey, test.secretKey)
+			if source.Name != test.expectedSource {
+				t.Errorf("expected source %q, got %q", test.expectedSource, source.Name)
+			}
+
+			value, err := source.Retrieve()
+			if test.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value.AccessKeyID != test.expectedKey || value.SecretAccessKey != test.expectedSecret {
+				t.Errorf("expected keys %s/%s, got %s/%s", test.expectedKey, test.expectedSecret, value.AccessKeyID, value.SecretAccessKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package lib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const rgwEndpointProbeTimeout = 5 * time.Second
+
+// RgwEndpointPool holds the gateways serving the same cluster. Calls go to
+// the current endpoint, and move to the next one when it fails.
+type RgwEndpointPool struct {
+	endpoints []*url.URL
+
+	mutex   sync.Mutex
+	current int
+}
+
+func NewRgwEndpointPool(endpoints []string) (*RgwEndpointPool, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoint configured")
+	}
+
+	pool := &RgwEndpointPool{}
+	for _, endpoint := range endpoints {
+		parsed, err := url.Parse(strings.TrimSuffix(strings.TrimSpace(endpoint), "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint %s: %w", endpoint, err)
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint %s: a scheme and a host are required", endpoint)
+		}
+		pool.endpoints = append(pool.endpoints, parsed)
+	}
+
+	return pool, nil
+}
+
+// Current returns the endpoint calls are sent to.
+func (p *RgwEndpointPool) Current() *url.URL {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.endpoints[p.current]
+}
+
+// Len returns the number of endpoints of the pool.
+func (p *RgwEndpointPool) Len() int {
+	return len(p.endpoints)
+}
+
+// Probe sends an anonymous request to every endpoint, starting with the
+// current one, and keeps the first that answers without a server error.
+func (p *RgwEndpointPool) Probe(ctx context.Context, httpClient *http.Client) error {
+	start := p.Current()
+
+	var errs []error
+	for i := range p.endpoints {
+		endpoint := p.endpoints[(p.indexOf(start)+i)%len(p.endpoints)]
+
+		err := probeEndpoint(ctx, httpClient, endpoint)
+		if err == nil {
+			p.mutex.Lock()
+			p.current = p.indexOf(endpoint)
+			p.mutex.Unlock()
+
+			tflog.Debug(ctx, "Ceph RGW endpoint is healthy", map[string]interface{}{
+				"endpoint": endpoint.String(),
+			})
+			return nil
+		}
+
+		tflog.Warn(ctx, "Ceph RGW endpoint is unhealthy", map[string]interface{}{
+			"endpoint": endpoint.String(),
+			"error":    err.Error(),
+		})
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func probeEndpoint(ctx context.Context, httpClient *http.Client, endpoint *url.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, rgwEndpointProbeTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String()+"/", nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	// Anonymous requests are usually denied, which still tells the gateway
+	// is up
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("HTTP %d", response.StatusCode)
+	}
+	return nil
+}
+
+// fail moves the pool to the endpoint following endpoint, unless another
+// call already did.
+func (p *RgwEndpointPool) fail(endpoint *url.URL) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.endpoints[p.current] == endpoint {
+		p.current = (p.current + 1) % len(p.endpoints)
+	}
+}
+
+func (p *RgwEndpointPool) indexOf(endpoint *url.URL) int {
+	for i, candidate := range p.endpoints {
+		if candidate == endpoint {
+			return i
+		}
+	}
+	return 0
+}
+
+// match returns the endpoint u points to, preferring the longest path when
+// several endpoints share a host.
+func (p *RgwEndpointPool) match(u *url.URL) *url.URL {
+	var matched *url.URL
+	for _, endpoint := range p.endpoints {
+		if endpoint.Scheme != u.Scheme || endpoint.Host != u.Host || !strings.HasPrefix(u.Path, endpoint.Path) {
+			continue
+		}
+		if matched == nil || len(endpoint.Path) > len(matched.Path) {
+			matched = endpoint
+		}
+	}
+	return matched
+}
+
+// point rewrites u, built against any endpoint of the pool, to target
+// endpoint instead.
+func (p *RgwEndpointPool) point(u *url.URL, endpoint *url.URL) {
+	from := p.match(u)
+	if from == nil || from == endpoint {
+		return
+	}
+
+	u.Scheme = endpoint.Scheme
+	u.Host = endpoint.Host
+	u.Path = endpoint.Path + strings.TrimPrefix(u.Path, from.Path)
+	u.RawPath = ""
+}
+
+// shouldFailover reports whether a call failed because of the gateway that
+// served it rather than because of the call itself.
+func shouldFailover(statusCode int, err error) bool {
+	if statusCode >= http.StatusInternalServerError {
+		return true
+	}
+	return err != nil && statusCode == 0 && ClassifyError(err) == ErrorClassTransient
+}
+
+func errorOrStatus(statusCode int, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("HTTP %d", statusCode)
+}
+
+// InstallHandlers makes the IAM and STS clients created from handlers send their
+// requests to the current endpoint, and mark it unhealthy on connection
+// errors and server errors. The failed request is then retried by the
+// retryer against the next endpoint, if it is a read or it was not sent.
+func (p *RgwEndpointPool) InstallHandlers(handlers *request.Handlers) {
+	handlers.Sign.PushFront(func(r *request.Request) {
+		p.point(r.HTTPRequest.URL, p.Current())
+		r.HTTPRequest.Host = ""
+	})
+
+	handlers.Retry.PushBack(func(r *request.Request) {
+		statusCode := 0
+		if r.HTTPResponse != nil {
+			statusCode = r.HTTPResponse.StatusCode
+		}
+		if !shouldFailover(statusCode, r.Error) || r.Context().Err() != nil {
+			return
+		}
+
+		if endpoint := p.match(r.HTTPRequest.URL); endpoint != nil {
+			tflog.Warn(r.Context(), "Ceph RGW endpoint failed, failing over", map[string]interface{}{
+				"endpoint": endpoint.String(),
+				"error":    r.Error.Error(),
+			})
+			p.fail(endpoint)
+		}
+		if p.Len() > 1 && ((r.Operation != nil && IsIdempotentOperation(r.Operation.Name)) || !IsRequestSent(r.Error)) {
+			r.Retryable = aws.Bool(true)
+		}
+	})
+
+	handlers.Complete.PushBack(func(r *request.Request) {
+		if endpoint := p.match(r.HTTPRequest.URL); endpoint != nil {
+			tflog.Debug(r.Context(), "Ceph RGW endpoint served call", map[string]interface{}{
+				"endpoint":  endpoint.String(),
+				"service":   r.ClientInfo.ServiceName,
+				"operation": r.Operation.Name,
+			})
+		}
+	})
+}
+
+// RgwFailoverHTTPClient sends the admin requests to the current endpoint of
+// the pool, and tries the other endpoints in turn on connection errors and
+// server errors. Like RgwRetryingHTTPClient, it only sends writes again when
+// they did not reach the gateway. It must wrap the client signing the
+// requests, so that the signature covers the host actually sent.
+type RgwFailoverHTTPClient struct {
+	Endpoints  *RgwEndpointPool
+	HTTPClient admin.HTTPClient
+}
+
+func (c *RgwFailoverHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request.Body = body
+		}
+
+		endpoint := c.Endpoints.Current()
+		c.Endpoints.point(request.URL, endpoint)
+		request.Host = ""
+
+		response, err := c.HTTPClient.Do(request)
+
+		statusCode := 0
+		if response != nil {
+			statusCode = response.StatusCode
+		}
+		resend := IsIdempotentMethod(request.Method) || !IsRequestSent(err)
+		if !shouldFailover(statusCode, err) || !resend || attempt >= c.Endpoints.Len()-1 || request.Context().Err() != nil {
+			tflog.Debug(request.Context(), "Ceph RGW endpoint served call", map[string]interface{}{
+				"endpoint": endpoint.String(),
+				"method":   request.Method,
+				"path":     request.URL.Path,
+			})
+			return response, err
+		}
+
+		tflog.Warn(request.Context(), "Ceph RGW endpoint failed, failing over", map[string]interface{}{
+			"endpoint": endpoint.String(),
+			"error":    errorOrStatus(statusCode, err),
+		})
+		c.Endpoints.fail(endpoint)
+
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+	}
+}
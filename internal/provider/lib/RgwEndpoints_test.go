@@ -0,0 +1,262 @@
+package lib
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// newTestGateway starts a gateway answering every request with status, and
+// counts the requests it serves.
+func newTestGateway(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// closedEndpoint returns an endpoint nothing listens on.
+func closedEndpoint(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+	return "http://" + address
+}
+
+func TestNewRgwEndpointPool(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpoints     []string
+		expected      string
+		expectedError string
+	}{
+		{name: "trailing slash", endpoints: []string{" http://rgw:8080/ "}, expected: "http://rgw:8080"},
+		{name: "path", endpoints: []string{"https://gateway/rgw", "https://gateway"}, expected: "https://gateway/rgw"},
+		{name: "no endpoint", endpoints: nil, expectedError: "no endpoint configured"},
+		{name: "no scheme", endpoints: []string{"rgw:8080"}, expectedError: "a scheme and a host are required"},
+		{name: "no host", endpoints: []string{"http://"}, expectedError: "a scheme and a host are required"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool, err := NewRgwEndpointPool(test.endpoints)
+			if test.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+					t.Fatalf("expected an error containing %q, got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if current := pool.Current().String(); current != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, current)
+			}
+		})
+	}
+}
+
+func TestRgwEndpointPoolPoint(t *testing.T) {
+	pool, err := NewRgwEndpointPool([]string{"http://rgw-a:8080", "https://gateway/rgw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, _ := url.Parse("http://rgw-a:8080/admin/user?uid=alice")
+	pool.point(u, pool.endpoints[1])
+	if u.String() != "https://gateway/rgw/admin/user?uid=alice" {
+		t.Errorf("unexpected URL %s", u)
+	}
+
+	pool.point(u, pool.endpoints[0])
+	if u.String() != "http://rgw-a:8080/admin/user?uid=alice" {
+		t.Errorf("unexpected URL %s", u)
+	}
+
+	// URLs of other hosts are left alone
+	u, _ = url.Parse("http://elsewhere/admin/user")
+	pool.point(u, pool.endpoints[1])
+	if u.String() != "http://elsewhere/admin/user" {
+		t.Errorf("unexpected URL %s", u)
+	}
+}
+
+func TestRgwEndpointPoolProbe(t *testing.T) {
+	failing, _ := newTestGateway(t, http.StatusServiceUnavailable)
+	healthy, _ := newTestGateway(t, http.StatusForbidden)
+
+	pool, err := NewRgwEndpointPool([]string{closedEndpoint(t), failing.URL, healthy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pool.Probe(context.Background(), http.DefaultClient); err != nil {
+		t.Fatal(err)
+	}
+	if pool.Current().String() != healthy.URL {
+		t.Errorf("expected %s, got %s", healthy.URL, pool.Current())
+	}
+
+	pool, err = NewRgwEndpointPool([]string{closedEndpoint(t), failing.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Probe(context.Background(), http.DefaultClient); err == nil {
+		t.Error("expected no healthy endpoint")
+	}
+}
+
+func TestRgwFailoverHTTPClient(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		firstStatus      int
+		firstUnreachable bool
+		firstRequests    int32
+		secondRequests   int32
+		expectedStatus   int
+		expectedCurrent  int
+	}{
+		{name: "success", method: http.MethodGet, firstStatus: http.StatusOK, firstRequests: 1, expectedStatus: http.StatusOK},
+		{name: "client error", method: http.MethodGet, firstStatus: http.StatusNotFound, firstRequests: 1, expectedStatus: http.StatusNotFound},
+		{name: "server error", method: http.MethodGet, firstStatus: http.StatusBadGateway, firstRequests: 1, secondRequests: 1, expectedStatus: http.StatusOK, expectedCurrent: 1},
+		{name: "connection refused", method: http.MethodGet, firstUnreachable: true, secondRequests: 1, expectedStatus: http.StatusOK, expectedCurrent: 1},
+		{name: "write on server error", method: http.MethodPost, firstStatus: http.StatusBadGateway, firstRequests: 1, expectedStatus: http.StatusBadGateway},
+		{name: "write on connection refused", method: http.MethodPut, firstUnreachable: true, secondRequests: 1, expectedStatus: http.StatusOK, expectedCurrent: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, firstRequests := newTestGateway(t, test.firstStatus)
+			second, secondRequests := newTestGateway(t, http.StatusOK)
+
+			firstURL := first.URL
+			if test.firstUnreachable {
+				firstURL = closedEndpoint(t)
+			}
+
+			pool, err := NewRgwEndpointPool([]string{firstURL, second.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := &RgwFailoverHTTPClient{Endpoints: pool, HTTPClient: http.DefaultClient}
+
+			request, err := http.NewRequest(test.method, firstURL+"/admin/user?uid=alice", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := client.Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response.Body.Close()
+
+			if response.StatusCode != test.expectedStatus {
+				t.Errorf("expected HTTP %d, got %d", test.expectedStatus, response.StatusCode)
+			}
+			if firstRequests.Load() != test.firstRequests || secondRequests.Load() != test.secondRequests {
+				t.Errorf("expected %d and %d requests, got %d and %d", test.firstRequests, test.secondRequests, firstRequests.Load(), secondRequests.Load())
+			}
+			if current := pool.Current(); current != pool.endpoints[test.expectedCurrent] {
+				t.Errorf("expected the pool to move to %s, got %s", pool.endpoints[test.expectedCurrent], current)
+			}
+		})
+	}
+}
+
+func TestRgwFailoverHTTPClientAllFailing(t *testing.T) {
+	first, firstRequests := newTestGateway(t, http.StatusServiceUnavailable)
+	second, secondRequests := newTestGateway(t, http.StatusServiceUnavailable)
+
+	pool, err := NewRgwEndpointPool([]string{first.URL, second.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &RgwFailoverHTTPClient{Endpoints: pool, HTTPClient: http.DefaultClient}
+
+	request, err := http.NewRequest(http.MethodGet, first.URL+"/admin/user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	// Every endpoint is tried once, and the last answer is returned
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected HTTP %d, got %d", http.StatusServiceUnavailable, response.StatusCode)
+	}
+	if firstRequests.Load() != 1 || secondRequests.Load() != 1 {
+		t.Errorf("expected 1 request to each endpoint, got %d and %d", firstRequests.Load(), secondRequests.Load())
+	}
+}
+
+func TestRgwEndpointPoolRetryHandler(t *testing.T) {
+	serverError := awserr.NewRequestFailure(awserr.New("InternalError", "", nil), http.StatusInternalServerError, "")
+	refused := awserr.New(request.ErrCodeRequestError, "send request failed", &url.Error{Op: "Post", URL: "http://rgw", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}})
+
+	tests := []struct {
+		name      string
+		operation string
+		status    int
+		err       error
+		retryable bool
+	}{
+		{name: "read on server error", operation: "GetRole", status: http.StatusInternalServerError, err: serverError, retryable: true},
+		{name: "write on server error", operation: "CreateAccessKey", status: http.StatusInternalServerError, err: serverError, retryable: false},
+		{name: "write on connection refused", operation: "CreateRole", err: refused, retryable: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool, err := NewRgwEndpointPool([]string{"http://rgw1:8080", "http://rgw2:8080"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var handlers request.Handlers
+			pool.InstallHandlers(&handlers)
+
+			httpRequest, err := http.NewRequest(http.MethodPost, "http://rgw1:8080/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &request.Request{
+				Operation:   &request.Operation{Name: test.operation},
+				HTTPRequest: httpRequest,
+				Error:       test.err,
+			}
+			if test.status != 0 {
+				r.HTTPResponse = &http.Response{StatusCode: test.status}
+			}
+			handlers.Retry.Run(r)
+
+			if actual := r.Retryable != nil && *r.Retryable; actual != test.retryable {
+				t.Errorf("expected retryable %t, got %t", test.retryable, actual)
+			}
+			if pool.Current() != pool.endpoints[1] {
+				t.Errorf("expected the pool to move to %s, got %s", pool.endpoints[1], pool.Current())
+			}
+		})
+	}
+}
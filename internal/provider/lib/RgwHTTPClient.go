@@ -0,0 +1,164 @@
+package lib
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	rgwDialTimeout           = 30 * time.Second
+	rgwTLSHandshakeTimeout   = 10 * time.Second
+	rgwResponseHeaderTimeout = time.Minute
+)
+
+// RgwTLSConfig holds the TLS settings of the connections to RGW. The client
+// certificate and key are either PEM contents or paths to PEM files.
+type RgwTLSConfig struct {
+	CACertFile         string
+	CACertPEM          string
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+}
+
+// RgwHTTPClientConfig holds the connection settings of the HTTP client. The
+// proxy settings of the environment apply when HTTPProxy or NoProxy is empty.
+type RgwHTTPClientConfig struct {
+	TLS       RgwTLSConfig
+	HTTPProxy string
+	NoProxy   string
+}
+
+// NewRgwHTTPClient returns the HTTP client shared by the admin, S3 and IAM
+// clients. It has no overall timeout, as object uploads can take long, but
+// gives up on gateways that do not answer.
+func NewRgwHTTPClient(config RgwHTTPClientConfig) (*http.Client, error) {
+	tlsConfig, err := config.TLS.build()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   rgwDialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = rgwTLSHandshakeTimeout
+	transport.ResponseHeaderTimeout = rgwResponseHeaderTimeout
+	transport.TLSClientConfig = tlsConfig
+
+	if config.HTTPProxy != "" || config.NoProxy != "" {
+		proxyConfig := httpproxy.FromEnvironment()
+		if config.HTTPProxy != "" {
+			if _, err := url.Parse(config.HTTPProxy); err != nil {
+				return nil, fmt.Errorf("invalid HTTP proxy: %w", err)
+			}
+			proxyConfig.HTTPProxy = config.HTTPProxy
+			proxyConfig.HTTPSProxy = config.HTTPProxy
+		}
+		if config.NoProxy != "" {
+			proxyConfig.NoProxy = config.NoProxy
+		}
+
+		proxy := proxyConfig.ProxyFunc()
+		transport.Proxy = func(request *http.Request) (*url.URL, error) {
+			return proxy(request.URL)
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func (c RgwTLSConfig) build() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CACertFile != "" || c.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if c.CACertFile != "" {
+			pem, err := os.ReadFile(c.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, errors.New("no PEM certificate found in CA certificate file " + c.CACertFile)
+			}
+		}
+
+		if c.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(c.CACertPEM)) {
+			return nil, errors.New("no PEM certificate found in CA certificate")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return nil, errors.New("both a client certificate and a client key are required")
+		}
+
+		certPEM, err := readPEM(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns value when it holds PEM data, or the contents of the file
+// it points to otherwise.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// RgwAdminPathHTTPClient sends the admin requests to the admin entry point
+// configured on the gateways, as go-ceph always uses "admin". It must wrap
+// the client signing the requests, so that the signature covers the path
+// actually sent.
+type RgwAdminPathHTTPClient struct {
+	AdminPath  string
+	HTTPClient admin.HTTPClient
+}
+
+func (c *RgwAdminPathHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	adminPath := "/" + strings.Trim(c.AdminPath, "/")
+	if adminPath != rgwAdminPath {
+		if i := strings.LastIndex(request.URL.Path, rgwAdminPath+"/"); i >= 0 {
+			request.URL.Path = request.URL.Path[:i] + adminPath + request.URL.Path[i+len(rgwAdminPath):]
+			request.URL.RawPath = ""
+		}
+	}
+
+	return c.HTTPClient.Do(request)
+}
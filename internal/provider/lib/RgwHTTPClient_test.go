@@ -0,0 +1,88 @@
+package lib
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewRgwHTTPClientProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		config   RgwHTTPClientConfig
+		url      string
+		expected string
+	}{
+		{
+			name:     "configured proxy",
+			config:   RgwHTTPClientConfig{HTTPProxy: "http://proxy:3128"},
+			url:      "https://rgw.example.com/admin/user",
+			expected: "http://proxy:3128",
+		},
+		{
+			name:     "configured proxy over environment",
+			env:      map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"},
+			config:   RgwHTTPClientConfig{HTTPProxy: "http://proxy:3128"},
+			url:      "https://rgw.example.com/admin/user",
+			expected: "http://proxy:3128",
+		},
+		{
+			name:     "configured proxy with environment exclusions",
+			env:      map[string]string{"NO_PROXY": ".example.com"},
+			config:   RgwHTTPClientConfig{HTTPProxy: "http://proxy:3128"},
+			url:      "https://rgw.example.com/admin/user",
+			expected: "",
+		},
+		{
+			name:     "environment proxy with configured exclusions",
+			env:      map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"},
+			config:   RgwHTTPClientConfig{NoProxy: "rgw.example.com"},
+			url:      "https://rgw.example.com/admin/user",
+			expected: "",
+		},
+		{
+			name:     "environment proxy outside configured exclusions",
+			env:      map[string]string{"HTTP_PROXY": "http://env-proxy:3128"},
+			config:   RgwHTTPClientConfig{NoProxy: "rgw.example.com"},
+			url:      "http://s3.example.com/data",
+			expected: "http://env-proxy:3128",
+		},
+		{
+			name:     "configured exclusions over environment",
+			env:      map[string]string{"HTTPS_PROXY": "http://env-proxy:3128", "NO_PROXY": "s3.example.com"},
+			config:   RgwHTTPClientConfig{NoProxy: "rgw.example.com"},
+			url:      "https://s3.example.com/data",
+			expected: "http://env-proxy:3128",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy"} {
+				t.Setenv(name, test.env[name])
+			}
+
+			client, err := NewRgwHTTPClient(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			request, err := http.NewRequest(http.MethodGet, test.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proxy, err := client.Transport.(*http.Transport).Proxy(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var actual string
+			if proxy != nil {
+				actual = proxy.String()
+			}
+			if actual != test.expected {
+				t.Errorf("expected proxy %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
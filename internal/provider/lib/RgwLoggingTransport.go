@@ -0,0 +1,241 @@
+package lib
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Log subsystems of the calls to RGW. Their level can be set on their own
+// with TF_LOG_PROVIDER_CEPH_ADMIN and TF_LOG_PROVIDER_CEPH_S3.
+const (
+	LogSubsystemAdmin = "ceph_admin"
+	LogSubsystemS3    = "ceph_s3"
+)
+
+// Bodies are only logged when they are text and small enough to be read
+// without holding objects in memory.
+const rgwLogMaxBodySize = 64 * 1024
+
+const rgwLogRedacted = "<redacted>"
+
+var (
+	rgwLogRedactedHeaders = []string{
+		"Authorization",
+		"Proxy-Authorization",
+		"X-Amz-Security-Token",
+		"X-Amz-Server-Side-Encryption-Customer-Key",
+		"X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key",
+		"Cookie",
+		"Set-Cookie",
+	}
+
+	rgwLogRedactedQueryParameters = []string{
+		"X-Amz-Signature",
+		"X-Amz-Credential",
+		"X-Amz-Security-Token",
+		"AWSAccessKeyId",
+		"Signature",
+		"access-key",
+		"secret-key",
+		"secret",
+		"password",
+	}
+
+	rgwLogRedactedBodyExpressions = []*regexp.Regexp{
+		// JSON of the admin API
+		regexp.MustCompile(`("(?:access_key|secret_key|access-key|secret-key|secret|password|Password|AccessKeyId|SecretAccessKey|SessionToken|session_token)"\s*:\s*")[^"]*`),
+		// XML of S3, IAM and STS
+		regexp.MustCompile(`(<(?:AccessKeyId|SecretAccessKey|SessionToken|Password|WebIdentityToken)>)[^<]*`),
+		// Form-encoded IAM and STS requests
+		regexp.MustCompile(`((?:^|&)(?:AccessKeyId|SecretAccessKey|SessionToken|WebIdentityToken|Token|Password|OldPassword|NewPassword)=)[^&]*`),
+	}
+)
+
+// RgwLoggingTransport logs the requests sent to RGW and their responses to a
+// tflog subsystem: method, URL, status and latency at DEBUG level, headers and
+// bodies at TRACE level. Keys, signatures and tokens are redacted.
+type RgwLoggingTransport struct {
+	Subsystem string
+	Transport http.RoundTripper
+}
+
+// NewRgwLoggingHTTPClient returns a copy of httpClient whose calls are logged
+// to subsystem.
+func NewRgwLoggingHTTPClient(httpClient *http.Client, subsystem string) *http.Client {
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	loggingClient := *httpClient
+	loggingClient.Transport = &RgwLoggingTransport{
+		Subsystem: subsystem,
+		Transport: transport,
+	}
+	return &loggingClient
+}
+
+func (t *RgwLoggingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(request.Context(), t.Subsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", strings.ToUpper(t.Subsystem)))
+
+	fields := map[string]interface{}{
+		"method": request.Method,
+		"url":    redactURL(request.URL),
+	}
+
+	tflog.SubsystemTrace(ctx, t.Subsystem, "Sending Ceph RGW request", fields, map[string]interface{}{
+		"headers": redactHeaders(request.Header),
+		"body":    requestBody(request),
+	})
+
+	start := time.Now()
+	response, err := t.Transport.RoundTrip(request)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.SubsystemDebug(ctx, t.Subsystem, "Ceph RGW request failed", fields, map[string]interface{}{
+			"error": err.Error(),
+		})
+		return response, err
+	}
+
+	fields["status"] = response.StatusCode
+	tflog.SubsystemDebug(ctx, t.Subsystem, "Received Ceph RGW response", fields)
+	tflog.SubsystemTrace(ctx, t.Subsystem, "Received Ceph RGW response body", fields, map[string]interface{}{
+		"headers": redactHeaders(response.Header),
+		"body":    responseBody(response),
+	})
+
+	return response, nil
+}
+
+// requestBody reads the body of request again through GetBody, so that the
+// body sent is left untouched.
+func requestBody(request *http.Request) string {
+	if request.Body == nil || request.Body == http.NoBody {
+		return ""
+	}
+	if request.GetBody == nil || !loggableBody(request.Header, request.ContentLength) {
+		return omittedBody(request.ContentLength)
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return omittedBody(request.ContentLength)
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(body, rgwLogMaxBodySize))
+	if err != nil {
+		return omittedBody(request.ContentLength)
+	}
+	return redactBody(content)
+}
+
+// responseBody reads the start of the body of response, and puts it back in
+// front of the rest for the caller.
+func responseBody(response *http.Response) string {
+	if response.Body == nil || response.Body == http.NoBody {
+		return ""
+	}
+	if !loggableBody(response.Header, response.ContentLength) {
+		return omittedBody(response.ContentLength)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(response.Body, rgwLogMaxBodySize+1))
+	response.Body = &prefixedReadCloser{
+		Reader: io.MultiReader(bytes.NewReader(content), response.Body),
+		Closer: response.Body,
+	}
+	if err != nil {
+		return omittedBody(response.ContentLength)
+	}
+
+	if len(content) > rgwLogMaxBodySize {
+		return redactBody(content[:rgwLogMaxBodySize]) + "... (truncated)"
+	}
+	return redactBody(content)
+}
+
+type prefixedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func loggableBody(header http.Header, contentLength int64) bool {
+	if contentLength > rgwLogMaxBodySize {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		// RGW answers admin calls without a content type
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "json") ||
+		strings.HasSuffix(mediaType, "xml") ||
+		mediaType == "application/x-www-form-urlencoded"
+}
+
+func omittedBody(contentLength int64) string {
+	if contentLength < 0 {
+		return "(omitted)"
+	}
+	return "(" + strconv.FormatInt(contentLength, 10) + " bytes omitted)"
+}
+
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+
+	// The query is only encoded again when needed, as that would turn the
+	// sub-resource markers such as "?acl" into "?acl="
+	query := redacted.Query()
+	found := false
+	for _, parameter := range rgwLogRedactedQueryParameters {
+		if query.Has(parameter) {
+			query.Set(parameter, rgwLogRedacted)
+			found = true
+		}
+	}
+	if found {
+		redacted.RawQuery = query.Encode()
+	}
+
+	return redacted.String()
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for name, values := range header {
+		redacted[name] = strings.Join(values, ", ")
+	}
+	for _, name := range rgwLogRedactedHeaders {
+		if header.Get(name) != "" {
+			redacted[http.CanonicalHeaderKey(name)] = rgwLogRedacted
+		}
+	}
+	return redacted
+}
+
+func redactBody(content []byte) string {
+	for _, expression := range rgwLogRedactedBodyExpressions {
+		content = expression.ReplaceAll(content, []byte("${1}"+rgwLogRedacted))
+	}
+	return string(content)
+}
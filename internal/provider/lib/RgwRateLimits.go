@@ -0,0 +1,110 @@
+package lib
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	RgwRateLimitScopeUser      = "user"
+	RgwRateLimitScopeBucket    = "bucket"
+	RgwRateLimitScopeAnonymous = "anon"
+)
+
+// RgwRateLimit limits the operations and bytes per minute and per gateway.
+// 0 means unlimited.
+type RgwRateLimit struct {
+	MaxReadOps    int64 `json:"max_read_ops"`
+	MaxWriteOps   int64 `json:"max_write_ops"`
+	MaxReadBytes  int64 `json:"max_read_bytes"`
+	MaxWriteBytes int64 `json:"max_write_bytes"`
+	Enabled       bool  `json:"enabled"`
+}
+
+// RgwGlobalRateLimits holds the rate limits applied by default to the users,
+// buckets and anonymous requests of the period.
+type RgwGlobalRateLimits struct {
+	User      RgwRateLimit `json:"user_ratelimit"`
+	Bucket    RgwRateLimit `json:"bucket_ratelimit"`
+	Anonymous RgwRateLimit `json:"anonymous_ratelimit"`
+}
+
+// Get returns the global rate limit of a scope.
+func (l RgwGlobalRateLimits) Get(scope string) RgwRateLimit {
+	switch scope {
+	case RgwRateLimitScopeUser:
+		return l.User
+	case RgwRateLimitScopeBucket:
+		return l.Bucket
+	default:
+		return l.Anonymous
+	}
+}
+
+func (l RgwRateLimit) values() url.Values {
+	return url.Values{
+		"max-read-ops":    {strconv.FormatInt(l.MaxReadOps, 10)},
+		"max-write-ops":   {strconv.FormatInt(l.MaxWriteOps, 10)},
+		"max-read-bytes":  {strconv.FormatInt(l.MaxReadBytes, 10)},
+		"max-write-bytes": {strconv.FormatInt(l.MaxWriteBytes, 10)},
+		"enabled":         {strconv.FormatBool(l.Enabled)},
+	}
+}
+
+func (c *RgwAdminClient) GetUserRateLimit(ctx context.Context, uid string) (RgwRateLimit, error) {
+	var out struct {
+		RateLimit RgwRateLimit `json:"user_ratelimit"`
+	}
+	err := c.Call(ctx, http.MethodGet, "/ratelimit", url.Values{
+		"ratelimit-scope": {RgwRateLimitScopeUser},
+		"uid":             {uid},
+	}, nil, &out)
+	return out.RateLimit, err
+}
+
+func (c *RgwAdminClient) SetUserRateLimit(ctx context.Context, uid string, limit RgwRateLimit) error {
+	args := limit.values()
+	args.Set("ratelimit-scope", RgwRateLimitScopeUser)
+	args.Set("uid", uid)
+	return c.Call(ctx, http.MethodPost, "/ratelimit", args, nil, nil)
+}
+
+func (c *RgwAdminClient) GetBucketRateLimit(ctx context.Context, bucket string) (RgwRateLimit, error) {
+	var out struct {
+		RateLimit RgwRateLimit `json:"bucket_ratelimit"`
+	}
+	err := c.Call(ctx, http.MethodGet, "/ratelimit", url.Values{
+		"ratelimit-scope": {RgwRateLimitScopeBucket},
+		"bucket":          {bucket},
+	}, nil, &out)
+	return out.RateLimit, err
+}
+
+func (c *RgwAdminClient) SetBucketRateLimit(ctx context.Context, bucket string, limit RgwRateLimit) error {
+	args := limit.values()
+	args.Set("ratelimit-scope", RgwRateLimitScopeBucket)
+	args.Set("bucket", bucket)
+	return c.Call(ctx, http.MethodPost, "/ratelimit", args, nil, nil)
+}
+
+func (c *RgwAdminClient) GetGlobalRateLimits(ctx context.Context) (RgwGlobalRateLimits, error) {
+	var limits RgwGlobalRateLimits
+	err := c.Call(ctx, http.MethodGet, "/ratelimit", url.Values{"global": {"true"}}, nil, &limits)
+	return limits, err
+}
+
+func (c *RgwAdminClient) GetGlobalRateLimit(ctx context.Context, scope string) (RgwRateLimit, error) {
+	limits, err := c.GetGlobalRateLimits(ctx)
+	return limits.Get(scope), err
+}
+
+// SetGlobalRateLimit sets the default rate limit of a scope in the period
+// configuration, the way `radosgw-admin global ratelimit set` does.
+func (c *RgwAdminClient) SetGlobalRateLimit(ctx context.Context, scope string, limit RgwRateLimit) error {
+	args := limit.values()
+	args.Set("ratelimit-scope", scope)
+	args.Set("global", "true")
+	return c.Call(ctx, http.MethodPost, "/ratelimit", args, nil, nil)
+}
@@ -0,0 +1,56 @@
+package lib
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type RgwRealm struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	CurrentPeriod string `json:"current_period"`
+	Epoch         int64  `json:"epoch"`
+}
+
+type RgwPeriod struct {
+	Id        string `json:"id"`
+	Epoch     int64  `json:"epoch"`
+	PeriodMap struct {
+		Id         string         `json:"id"`
+		ZoneGroups []RgwZoneGroup `json:"zonegroups"`
+	} `json:"period_map"`
+	MasterZoneGroup string `json:"master_zonegroup"`
+	MasterZone      string `json:"master_zone"`
+	RealmId         string `json:"realm_id"`
+	RealmEpoch      int64  `json:"realm_epoch"`
+}
+
+// GetRealm returns the realm with the given id or name, or the default realm
+// when both are empty.
+func (c *RgwAdminClient) GetRealm(ctx context.Context, id string, name string) (RgwRealm, error) {
+	args := url.Values{}
+	if id != "" {
+		args.Set("id", id)
+	}
+	if name != "" {
+		args.Set("name", name)
+	}
+
+	var realm RgwRealm
+	err := c.Call(ctx, http.MethodGet, "/realm", args, nil, &realm)
+	return realm, err
+}
+
+// GetPeriod returns the current period of the realm, or of the default realm
+// when realmId is empty.
+func (c *RgwAdminClient) GetPeriod(ctx context.Context, realmId string) (RgwPeriod, error) {
+	args := url.Values{}
+	if realmId != "" {
+		args.Set("realm_id", realmId)
+	}
+
+	var period RgwPeriod
+	err := c.Call(ctx, http.MethodGet, "/realm/period", args, nil, &period)
+	return period, err
+}
@@ -0,0 +1,147 @@
+package lib
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	smithyendpoints "github.com/aws/smithy-go/endpoints"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RgwS3ClientConfig holds the settings of the S3 client.
+type RgwS3ClientConfig struct {
+	Region      string
+	Endpoints   *RgwEndpointPool
+	Credentials *credentials.Credentials
+	HTTPClient  *http.Client
+	MaxRetries  int
+	MaxBackoff  time.Duration
+}
+
+// NewRgwS3Client returns an S3 client addressing buckets by path, as RGW
+// does not require a wildcard DNS record. Requests are sent to the current
+// endpoint of the pool, and failing over goes through the retryer, which then
+// tries every endpoint.
+func NewRgwS3Client(config RgwS3ClientConfig) *s3.Client {
+	return s3.New(s3.Options{
+		Region:       config.Region,
+		UsePathStyle: true,
+		EndpointResolverV2: &rgwS3EndpointResolver{
+			Endpoints: config.Endpoints,
+			Resolver:  s3.NewDefaultEndpointResolverV2(),
+		},
+		Credentials: RgwCredentialsProvider{Credentials: config.Credentials},
+		HTTPClient:  config.HTTPClient,
+		Retryer:     NewRgwS3Retryer(max(config.MaxRetries, config.Endpoints.Len()-1), config.MaxBackoff),
+		// Older gateways reject the checksums the SDK sends by default
+		RequestChecksumCalculation: awsv2.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: awsv2.ResponseChecksumValidationWhenRequired,
+		APIOptions: []func(*middleware.Stack) error{
+			config.Endpoints.addS3Middleware,
+		},
+	})
+}
+
+// NewRgwS3Retryer retries the S3 requests that fail with a throttled or
+// transient error, like RgwRetryer does for IAM and STS.
+func NewRgwS3Retryer(maxRetries int, maxBackoff time.Duration) awsv2.Retryer {
+	return retry.NewStandard(func(options *retry.StandardOptions) {
+		options.MaxAttempts = maxRetries + 1
+		options.MaxBackoff = maxBackoff
+		options.Backoff = retry.BackoffDelayerFunc(func(attempt int, _ error) (time.Duration, error) {
+			return RetryBackoff(attempt-1, maxBackoff), nil
+		})
+		options.Retryables = []retry.IsErrorRetryable{
+			retry.IsErrorRetryableFunc(func(err error) awsv2.Ternary {
+				return awsv2.BoolTernary(IsRetryable(err))
+			}),
+		}
+		options.RateLimiter = ratelimit.None
+	})
+}
+
+// RgwCredentialsProvider hands the credentials of the provider, which may be
+// refreshed when they come from an assumed role, to the S3 client.
+type RgwCredentialsProvider struct {
+	Credentials *credentials.Credentials
+}
+
+func (p RgwCredentialsProvider) Retrieve(ctx context.Context) (awsv2.Credentials, error) {
+	value, err := p.Credentials.GetWithContext(ctx)
+	if err != nil {
+		return awsv2.Credentials{}, err
+	}
+
+	result := awsv2.Credentials{
+		AccessKeyID:     value.AccessKeyID,
+		SecretAccessKey: value.SecretAccessKey,
+		SessionToken:    value.SessionToken,
+		Source:          value.ProviderName,
+	}
+	if expiresAt, err := p.Credentials.ExpiresAt(); err == nil {
+		result.CanExpire = true
+		result.Expires = expiresAt
+	}
+	return result, nil
+}
+
+// rgwS3EndpointResolver resolves every attempt against the current endpoint
+// of the pool.
+type rgwS3EndpointResolver struct {
+	Endpoints *RgwEndpointPool
+	Resolver  s3.EndpointResolverV2
+}
+
+func (r *rgwS3EndpointResolver) ResolveEndpoint(ctx context.Context, params s3.EndpointParameters) (smithyendpoints.Endpoint, error) {
+	params.Endpoint = awsv2.String(r.Endpoints.Current().String())
+	return r.Resolver.ResolveEndpoint(ctx, params)
+}
+
+// addS3Middleware marks the endpoint that served an attempt unhealthy on
+// connection errors and server errors, so that the next attempt goes to the
+// following endpoint.
+func (p *RgwEndpointPool) addS3Middleware(stack *middleware.Stack) error {
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("RgwEndpointFailover", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		request, ok := in.Request.(*smithyhttp.Request)
+		if !ok {
+			return next.HandleFinalize(ctx, in)
+		}
+
+		out, metadata, err := next.HandleFinalize(ctx, in)
+
+		endpoint := p.match(request.URL)
+		if endpoint == nil {
+			return out, metadata, err
+		}
+
+		statusCode := ErrorStatusCode(err)
+		if response, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok && response != nil {
+			statusCode = response.StatusCode
+		}
+
+		if shouldFailover(statusCode, err) && ctx.Err() == nil {
+			tflog.Warn(ctx, "Ceph RGW endpoint failed, failing over", map[string]interface{}{
+				"endpoint": endpoint.String(),
+				"error":    errorOrStatus(statusCode, err),
+			})
+			p.fail(endpoint)
+		} else {
+			tflog.Debug(ctx, "Ceph RGW endpoint served call", map[string]interface{}{
+				"endpoint":  endpoint.String(),
+				"service":   awsmiddleware.GetServiceID(ctx),
+				"operation": awsmiddleware.GetOperationName(ctx),
+			})
+		}
+
+		return out, metadata, err
+	}), middleware.After)
+}
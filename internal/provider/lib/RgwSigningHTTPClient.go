@@ -0,0 +1,49 @@
+package lib
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/ceph/go-ceph/rgw/admin"
+)
+
+const (
+	rgwAdminAuthRegion = "default"
+	rgwAdminService    = "s3"
+)
+
+// RgwSigningHTTPClient signs admin requests with the provider
+// credentials before sending them. go-ceph's admin.API signs with the keys it
+// was created with and without a session token, which does not work with
+// temporary credentials that get refreshed during an apply.
+type RgwSigningHTTPClient struct {
+	Credentials *credentials.Credentials
+	HTTPClient  admin.HTTPClient
+}
+
+func (c *RgwSigningHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	for _, header := range []string{"Authorization", "X-Amz-Date", "X-Amz-Content-Sha256", "X-Amz-Security-Token"} {
+		request.Header.Del(header)
+	}
+
+	var body io.ReadSeeker
+	if request.Body != nil && request.Body != http.NoBody {
+		payload, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		request.Body.Close()
+		body = bytes.NewReader(payload)
+	}
+
+	_, err := v4.NewSigner(c.Credentials).Sign(request, body, rgwAdminService, rgwAdminAuthRegion, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.HTTPClient.Do(request)
+}
@@ -0,0 +1,171 @@
+package lib
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const (
+	RgwDefaultPlacement     = "default-placement"
+	RgwStandardStorageClass = "STANDARD"
+)
+
+type RgwPlacementTarget struct {
+	Name           string   `json:"name"`
+	Tags           []string `json:"tags"`
+	StorageClasses []string `json:"storage_classes"`
+}
+
+type RgwZoneGroupZone struct {
+	Id        string   `json:"id"`
+	Name      string   `json:"name"`
+	Endpoints []string `json:"endpoints"`
+	TierType  string   `json:"tier_type"`
+}
+
+type RgwZoneGroup struct {
+	Id               string               `json:"id"`
+	Name             string               `json:"name"`
+	ApiName          string               `json:"api_name"`
+	Endpoints        []string             `json:"endpoints"`
+	MasterZone       string               `json:"master_zone"`
+	Zones            []RgwZoneGroupZone   `json:"zones"`
+	PlacementTargets []RgwPlacementTarget `json:"placement_targets"`
+	DefaultPlacement string               `json:"default_placement"`
+	RealmId          string               `json:"realm_id"`
+}
+
+type RgwZoneGroupMap struct {
+	ZoneGroups []struct {
+		Key string       `json:"key"`
+		Val RgwZoneGroup `json:"val"`
+	} `json:"zonegroups"`
+	MasterZoneGroup string `json:"master_zonegroup"`
+}
+
+// RgwZoneGroups lists the zonegroups of the current period, or those of the
+// zonegroup map when the gateway is not part of a realm.
+type RgwZoneGroups struct {
+	ZoneGroups      []RgwZoneGroup
+	MasterZoneGroup string
+}
+
+// GetZoneGroupMap returns the zonegroups known to the gateway.
+func (c *RgwAdminClient) GetZoneGroupMap(ctx context.Context) (RgwZoneGroupMap, error) {
+	var zoneGroupMap RgwZoneGroupMap
+	err := c.Call(ctx, http.MethodGet, "/config", url.Values{"type": {"zonegroup-map"}}, nil, &zoneGroupMap)
+	return zoneGroupMap, err
+}
+
+// GetZoneGroups returns the zonegroups of the current period, falling back to
+// the zonegroup map for gateways that are not part of a realm.
+func (c *RgwAdminClient) GetZoneGroups(ctx context.Context) (RgwZoneGroups, error) {
+	period, err := c.GetPeriod(ctx, "")
+	if err == nil {
+		return RgwZoneGroups{
+			ZoneGroups:      period.PeriodMap.ZoneGroups,
+			MasterZoneGroup: period.MasterZoneGroup,
+		}, nil
+	}
+
+	zoneGroupMap, mapErr := c.GetZoneGroupMap(ctx)
+	if mapErr != nil {
+		return RgwZoneGroups{}, mapErr
+	}
+
+	zoneGroups := RgwZoneGroups{MasterZoneGroup: zoneGroupMap.MasterZoneGroup}
+	for _, entry := range zoneGroupMap.ZoneGroups {
+		zoneGroups.ZoneGroups = append(zoneGroups.ZoneGroups, entry.Val)
+	}
+
+	return zoneGroups, nil
+}
+
+// FindZoneGroup looks a zonegroup up by its name or API name, which is what
+// S3 clients use as region.
+func (z RgwZoneGroups) FindZoneGroup(name string) (RgwZoneGroup, bool) {
+	for _, zoneGroup := range z.ZoneGroups {
+		if zoneGroup.Name == name || zoneGroup.ApiName == name {
+			return zoneGroup, true
+		}
+	}
+
+	return RgwZoneGroup{}, false
+}
+
+// GetMasterZoneGroup returns the master zonegroup.
+func (z RgwZoneGroups) GetMasterZoneGroup() (RgwZoneGroup, bool) {
+	for _, zoneGroup := range z.ZoneGroups {
+		if zoneGroup.Id == z.MasterZoneGroup {
+			return zoneGroup, true
+		}
+	}
+
+	return RgwZoneGroup{}, false
+}
+
+// FindZoneGroupOfZone returns the zonegroup the zone belongs to.
+func (z RgwZoneGroups) FindZoneGroupOfZone(zoneId string) (RgwZoneGroup, bool) {
+	for _, zoneGroup := range z.ZoneGroups {
+		if _, found := zoneGroup.FindZone(zoneId); found {
+			return zoneGroup, true
+		}
+	}
+
+	return RgwZoneGroup{}, false
+}
+
+// FindZone returns the zone of the zonegroup with the given id.
+func (z RgwZoneGroup) FindZone(zoneId string) (RgwZoneGroupZone, bool) {
+	for _, zone := range z.Zones {
+		if zone.Id == zoneId {
+			return zone, true
+		}
+	}
+
+	return RgwZoneGroupZone{}, false
+}
+
+// FindPlacementTarget returns the placement target with the given name.
+func (z RgwZoneGroup) FindPlacementTarget(name string) (RgwPlacementTarget, bool) {
+	for _, target := range z.PlacementTargets {
+		if target.Name == name {
+			return target, true
+		}
+	}
+
+	return RgwPlacementTarget{}, false
+}
+
+// GetDefaultPlacement returns the placement target new buckets land in when
+// none is requested.
+func (z RgwZoneGroup) GetDefaultPlacement() string {
+	if z.DefaultPlacement == "" {
+		return RgwDefaultPlacement
+	}
+	return z.DefaultPlacement
+}
+
+// HasStorageClass reports whether the placement target offers the storage
+// class. STANDARD is always available.
+func (t RgwPlacementTarget) HasStorageClass(storageClass string) bool {
+	if storageClass == RgwStandardStorageClass {
+		return true
+	}
+
+	for _, candidate := range t.StorageClasses {
+		if candidate == storageClass {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t RgwPlacementTarget) GetStorageClasses() []string {
+	if len(t.StorageClasses) == 0 {
+		return []string{RgwStandardStorageClass}
+	}
+	return t.StorageClasses
+}
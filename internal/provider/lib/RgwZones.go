@@ -0,0 +1,38 @@
+package lib
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type RgwZoneStorageClass struct {
+	DataPool        string `json:"data_pool"`
+	CompressionType string `json:"compression_type"`
+}
+
+type RgwZonePlacementPool struct {
+	IndexPool      string                         `json:"index_pool"`
+	StorageClasses map[string]RgwZoneStorageClass `json:"storage_classes"`
+	DataExtraPool  string                         `json:"data_extra_pool"`
+	IndexType      int64                          `json:"index_type"`
+}
+
+type RgwZonePlacementPoolEntry struct {
+	Key string               `json:"key"`
+	Val RgwZonePlacementPool `json:"val"`
+}
+
+type RgwZone struct {
+	Id             string                      `json:"id"`
+	Name           string                      `json:"name"`
+	RealmId        string                      `json:"realm_id"`
+	PlacementPools []RgwZonePlacementPoolEntry `json:"placement_pools"`
+}
+
+// GetZone returns the configuration of the zone served by the gateway.
+func (c *RgwAdminClient) GetZone(ctx context.Context) (RgwZone, error) {
+	var zone RgwZone
+	err := c.Call(ctx, http.MethodGet, "/config", url.Values{"type": {"zone"}}, nil, &zone)
+	return zone, err
+}
@@ -0,0 +1,63 @@
+package lib
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// S3 multipart upload limits, matching the ones enforced by RGW.
+const (
+	S3MultipartPartSize int64 = 5 * 1024 * 1024
+	S3MaxUploadParts    int64 = 10000
+)
+
+// S3PartSizeFor returns the part size used to upload an object of the given
+// size, growing the default part size when the object would not fit in
+// S3MaxUploadParts parts.
+func S3PartSizeFor(size int64) int64 {
+	partSize := S3MultipartPartSize
+	if size/partSize >= S3MaxUploadParts {
+		partSize = size/S3MaxUploadParts + 1
+	}
+	return partSize
+}
+
+// ComputeS3Etag computes the ETag RGW reports for an object uploaded from r.
+// Objects no larger than partSize are uploaded in a single request and their
+// ETag is the MD5 of the content; larger objects are uploaded in parts and
+// their ETag is the MD5 of the concatenated part digests suffixed with the
+// number of parts.
+func ComputeS3Etag(r io.Reader, size int64, partSize int64) (string, error) {
+	if size <= partSize {
+		hash := md5.New()
+		if _, err := io.Copy(hash, r); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	var digests []byte
+	var parts int64
+	for remaining := size; remaining > 0; remaining -= partSize {
+		hash := md5.New()
+		if _, err := io.CopyN(hash, r, min(partSize, remaining)); err != nil {
+			return "", err
+		}
+		digests = hash.Sum(digests)
+		parts++
+	}
+
+	sum := md5.Sum(digests)
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), parts), nil
+}
+
+// NormalizeS3Etag strips the quotes S3 wraps ETags in.
+func NormalizeS3Etag(etag *string) string {
+	if etag == nil {
+		return ""
+	}
+	return strings.Trim(*etag, "\"")
+}
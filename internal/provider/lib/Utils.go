@@ -8,11 +8,11 @@ func ConvertInt32ToIntPointer(i *int32) *int {
 	return &v
 }
 
-func ConvertInt64ToIntPointer(i *int64) *int64 {
+func ConvertInt64ToInt32Pointer(i *int64) *int32 {
 	if i == nil {
 		return nil
 	}
-	v := *i
+	v := int32(*i)
 	return &v
 }
 
@@ -31,3 +31,19 @@ func ConvertStringToStringPointer(s *string) *string {
 	v := *s
 	return &v
 }
+
+// Difference returns the values of a that are not in b.
+func Difference(a, b []string) []string {
+	excluded := map[string]bool{}
+	for _, value := range b {
+		excluded[value] = true
+	}
+
+	result := []string{}
+	for _, value := range a {
+		if !excluded[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"context"
+	"terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwAccountQuota struct {
+	Enabled    types.Bool  `tfsdk:"enabled"`
+	MaxSize    types.Int64 `tfsdk:"max_size"`
+	MaxObjects types.Int64 `tfsdk:"max_objects"`
+}
+
+type RgwAccount struct {
+	Id         types.String     `tfsdk:"id"`
+	Name       types.String     `tfsdk:"name"`
+	Email      types.String     `tfsdk:"email"`
+	MaxUsers   types.Int64      `tfsdk:"max_users"`
+	MaxRoles   types.Int64      `tfsdk:"max_roles"`
+	MaxGroups  types.Int64      `tfsdk:"max_groups"`
+	MaxBuckets types.Int64      `tfsdk:"max_buckets"`
+	Quota      *RgwAccountQuota `tfsdk:"quota"`
+	Timeouts   timeouts.Value   `tfsdk:"timeouts"`
+}
+
+// ToRgwAccount converts an account. The quota is only read back when prior
+// manages it or when it is enabled on the account.
+func ToRgwAccount(account lib.RgwAccount, prior RgwAccount) RgwAccount {
+	data := RgwAccount{
+		Id:         types.StringValue(account.Id),
+		Name:       types.StringValue(account.Name),
+		Email:      types.StringValue(account.Email),
+		MaxUsers:   types.Int64Value(account.MaxUsers),
+		MaxRoles:   types.Int64Value(account.MaxRoles),
+		MaxGroups:  types.Int64Value(account.MaxGroups),
+		MaxBuckets: types.Int64Value(account.MaxBuckets),
+		Timeouts:   prior.Timeouts,
+	}
+
+	enabled := account.Quota.Enabled != nil && *account.Quota.Enabled
+	if prior.Quota != nil || enabled {
+		quota := RgwAccountQuota{
+			Enabled:    types.BoolValue(enabled),
+			MaxSize:    types.Int64Value(-1),
+			MaxObjects: types.Int64Value(-1),
+		}
+		if account.Quota.MaxSize != nil {
+			quota.MaxSize = types.Int64Value(*account.Quota.MaxSize)
+		}
+		if account.Quota.MaxObjects != nil {
+			quota.MaxObjects = types.Int64Value(*account.Quota.MaxObjects)
+		}
+		data.Quota = &quota
+	}
+
+	return data
+}
+
+// ToRgwAccountSpec converts the planned account. Limits that are not
+// configured are left out, so that RGW applies its defaults.
+func ToRgwAccountSpec(data RgwAccount) lib.RgwAccountSpec {
+	return lib.RgwAccountSpec{
+		Id:         data.Id.ValueString(),
+		Name:       data.Name.ValueString(),
+		Email:      data.Email.ValueString(),
+		MaxUsers:   knownInt64Pointer(data.MaxUsers),
+		MaxRoles:   knownInt64Pointer(data.MaxRoles),
+		MaxGroups:  knownInt64Pointer(data.MaxGroups),
+		MaxBuckets: knownInt64Pointer(data.MaxBuckets),
+	}
+}
+
+// knownInt64Pointer returns nil for a null or unknown value, which
+// ValueInt64Pointer only does for null ones.
+func knownInt64Pointer(value types.Int64) *int64 {
+	if value.IsUnknown() {
+		return nil
+	}
+	return value.ValueInt64Pointer()
+}
+
+func ToRgwAccountQuota(quota RgwAccountQuota) lib.RgwAccountQuota {
+	return lib.RgwAccountQuota{
+		Enabled:    quota.Enabled.ValueBool(),
+		MaxSize:    quota.MaxSize.ValueInt64(),
+		MaxObjects: quota.MaxObjects.ValueInt64(),
+	}
+}
+
+func GetRgwAccountResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"id": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": resource.StringAttribute{
+				Required: true,
+			},
+			"email": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"max_users": resource.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_roles": resource.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_groups": resource.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_buckets": resource.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"quota": resource.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]resource.Attribute{
+					"enabled": resource.BoolAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(true),
+					},
+					"max_size": resource.Int64Attribute{
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(-1),
+					},
+					"max_objects": resource.Int64Attribute{
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(-1),
+					},
+				},
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
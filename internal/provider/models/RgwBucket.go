@@ -5,13 +5,18 @@ import (
 	"encoding/json"
 	"strings"
 	"terraform-provider-ceph/internal/provider/lib"
+	"time"
 
-	"github.com/aws/aws-sdk-go/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	datasource "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -30,17 +35,183 @@ type RgwLifecycleDelete struct {
 type RgwBucket struct {
 	Name                      types.String         `tfsdk:"name"`
 	PlacementRule             types.String         `tfsdk:"placement_rule"`
+	StorageClass              types.String         `tfsdk:"storage_class"`
 	Permissions               []RgwPermission      `tfsdk:"permission"`
 	LifecycleDelete           []RgwLifecycleDelete `tfsdk:"lifecycle_delete"`
 	LifecycleDeleteNonCurrent []RgwLifecycleDelete `tfsdk:"lifecycle_delete_noncurrent"`
 	VersioningEnabled         types.Bool           `tfsdk:"versioning_enabled"`
+	NumShards                 types.Int64          `tfsdk:"num_shards"`
+	Timeouts                  timeouts.Value       `tfsdk:"timeouts"`
+}
+
+type RgwBucketUsage struct {
+	Size         types.Int64 `tfsdk:"size"`
+	SizeActual   types.Int64 `tfsdk:"size_actual"`
+	SizeUtilized types.Int64 `tfsdk:"size_utilized"`
+	NumObjects   types.Int64 `tfsdk:"num_objects"`
+}
+
+type RgwBucketQuota struct {
+	Enabled    types.Bool  `tfsdk:"enabled"`
+	MaxSize    types.Int64 `tfsdk:"max_size"`
+	MaxObjects types.Int64 `tfsdk:"max_objects"`
+}
+
+// RgwBucketStats is what the admin API reports about a bucket.
+type RgwBucketStats struct {
+	Name          types.String   `tfsdk:"name"`
+	PlacementRule types.String   `tfsdk:"placement_rule"`
+	StorageClass  types.String   `tfsdk:"storage_class"`
+	Id            types.String   `tfsdk:"id"`
+	Marker        types.String   `tfsdk:"marker"`
+	Owner         types.String   `tfsdk:"owner"`
+	ZoneGroup     types.String   `tfsdk:"zonegroup"`
+	CreationTime  types.String   `tfsdk:"creation_time"`
+	NumShards     types.Int64    `tfsdk:"num_shards"`
+	Usage         RgwBucketUsage `tfsdk:"usage"`
+	Quota         RgwBucketQuota `tfsdk:"quota"`
+}
+
+type RgwBucketDataSourceModel struct {
+	Name                      types.String         `tfsdk:"name"`
+	PlacementRule             types.String         `tfsdk:"placement_rule"`
+	StorageClass              types.String         `tfsdk:"storage_class"`
+	Permissions               []RgwPermission      `tfsdk:"permission"`
+	LifecycleDelete           []RgwLifecycleDelete `tfsdk:"lifecycle_delete"`
+	LifecycleDeleteNonCurrent []RgwLifecycleDelete `tfsdk:"lifecycle_delete_noncurrent"`
+	VersioningEnabled         types.Bool           `tfsdk:"versioning_enabled"`
+	Id                        types.String         `tfsdk:"id"`
+	Marker                    types.String         `tfsdk:"marker"`
+	Owner                     types.String         `tfsdk:"owner"`
+	ZoneGroup                 types.String         `tfsdk:"zonegroup"`
+	CreationTime              types.String         `tfsdk:"creation_time"`
+	NumShards                 types.Int64          `tfsdk:"num_shards"`
+	Usage                     *RgwBucketUsage      `tfsdk:"usage"`
+	Quota                     *RgwBucketQuota      `tfsdk:"quota"`
+	ReshardStatus             types.String         `tfsdk:"reshard_status"`
 }
 
 func ToRgwBucket(bucket admin.Bucket) RgwBucket {
+	// RGW reports the placement rule as "<placement>/<storage class>" when
+	// the bucket does not default to the STANDARD storage class.
+	placementRule, storageClass, _ := strings.Cut(bucket.PlacementRule, "/")
+	if storageClass == "" {
+		storageClass = lib.RgwStandardStorageClass
+	}
+
+	var numShards uint64
+	if bucket.NumShards != nil {
+		numShards = *bucket.NumShards
+	}
+
 	return RgwBucket{
 		Name:          types.StringValue(bucket.Bucket),
-		PlacementRule: types.StringValue(bucket.PlacementRule),
+		PlacementRule: types.StringValue(placementRule),
+		StorageClass:  types.StringValue(storageClass),
+		NumShards:     types.Int64Value(int64(numShards)),
+	}
+}
+
+func ToRgwBucketStats(bucket admin.Bucket) RgwBucketStats {
+	data := ToRgwBucket(bucket)
+
+	creationTime := types.StringNull()
+	if bucket.CreationTime != nil {
+		creationTime = types.StringValue(bucket.CreationTime.UTC().Format(time.RFC3339))
+	}
+
+	// Multipart uploads in progress are accounted apart from the objects
+	var usage = func(size func(admin.RgwUsage) *uint64) types.Int64 {
+		var total uint64
+		for _, category := range []admin.RgwUsage{bucket.Usage.RgwMain, bucket.Usage.RgwMultimeta} {
+			if value := size(category); value != nil {
+				total += *value
+			}
+		}
+		return types.Int64Value(int64(total))
+	}
+
+	quota := RgwBucketQuota{
+		Enabled:    types.BoolValue(bucket.BucketQuota.Enabled != nil && *bucket.BucketQuota.Enabled),
+		MaxSize:    types.Int64Value(-1),
+		MaxObjects: types.Int64Value(-1),
+	}
+	if bucket.BucketQuota.MaxSize != nil {
+		quota.MaxSize = types.Int64Value(*bucket.BucketQuota.MaxSize)
+	}
+	if bucket.BucketQuota.MaxObjects != nil {
+		quota.MaxObjects = types.Int64Value(*bucket.BucketQuota.MaxObjects)
+	}
+
+	return RgwBucketStats{
+		Name:          data.Name,
+		PlacementRule: data.PlacementRule,
+		StorageClass:  data.StorageClass,
+		Id:            types.StringValue(bucket.ID),
+		Marker:        types.StringValue(bucket.Marker),
+		Owner:         types.StringValue(bucket.Owner),
+		ZoneGroup:     types.StringValue(bucket.Zonegroup),
+		CreationTime:  creationTime,
+		NumShards:     data.NumShards,
+		Usage: RgwBucketUsage{
+			Size:         usage(func(u admin.RgwUsage) *uint64 { return u.Size }),
+			SizeActual:   usage(func(u admin.RgwUsage) *uint64 { return u.SizeActual }),
+			SizeUtilized: usage(func(u admin.RgwUsage) *uint64 { return u.SizeUtilized }),
+			NumObjects:   usage(func(u admin.RgwUsage) *uint64 { return u.NumObjects }),
+		},
+		Quota: quota,
+	}
+}
+
+func ToRgwBucketDataSourceModel(data RgwBucket, stats RgwBucketStats, reshardStatus types.String) RgwBucketDataSourceModel {
+	return RgwBucketDataSourceModel{
+		Name:                      data.Name,
+		PlacementRule:             data.PlacementRule,
+		StorageClass:              data.StorageClass,
+		Permissions:               data.Permissions,
+		LifecycleDelete:           data.LifecycleDelete,
+		LifecycleDeleteNonCurrent: data.LifecycleDeleteNonCurrent,
+		VersioningEnabled:         data.VersioningEnabled,
+		Id:                        stats.Id,
+		Marker:                    stats.Marker,
+		Owner:                     stats.Owner,
+		ZoneGroup:                 stats.ZoneGroup,
+		CreationTime:              stats.CreationTime,
+		NumShards:                 stats.NumShards,
+		Usage:                     &stats.Usage,
+		Quota:                     &stats.Quota,
+		ReshardStatus:             reshardStatus,
+	}
+}
+
+// NormalizeRgwBucketPlacement keeps the configured placement rule when RGW
+// reports an equivalent one, as an empty placement rule stands for the
+// zonegroup default placement.
+func NormalizeRgwBucketPlacement(bucket *RgwBucket, prior types.String, defaultPlacement func() string) {
+	var remote = bucket.PlacementRule.ValueString()
+	var priorKnown = !prior.IsNull() && !prior.IsUnknown()
+
+	if remote != "" && !(priorKnown && prior.ValueString() == "") {
+		return
+	}
+
+	var placement = defaultPlacement()
+	if remote == "" {
+		bucket.PlacementRule = types.StringValue(placement)
+	}
+
+	if priorKnown && prior.ValueString() == "" && bucket.PlacementRule.ValueString() == placement {
+		bucket.PlacementRule = prior
+	}
+}
+
+// GetRgwBucketLocationConstraint builds the LocationConstraint RGW expects to
+// select a placement target and default storage class at bucket creation.
+func GetRgwBucketLocationConstraint(region string, placement string, storageClass string) string {
+	if storageClass != "" && storageClass != lib.RgwStandardStorageClass {
+		placement = placement + "/" + storageClass
 	}
+	return region + ":" + placement
 }
 
 func GenerateS3BucketPolicyFromBucket(bucket *RgwBucket) S3BucketPolicy {
@@ -95,46 +266,45 @@ func UnmarshalBucketPolicy(policyJson string) (S3BucketPolicy, error) {
 	return policy, err
 }
 
-func GenerateS3LifecyclePolicyFromBucket(bucket *RgwBucket) s3.BucketLifecycleConfiguration {
+func GenerateS3LifecyclePolicyFromBucket(bucket *RgwBucket) s3types.BucketLifecycleConfiguration {
 
-	rules := []*s3.LifecycleRule{}
-	status := "Enabled"
+	rules := []s3types.LifecycleRule{}
 
 	for _, lifecycleDelete := range bucket.LifecycleDelete {
-		rule := s3.LifecycleRule{
+		rule := s3types.LifecycleRule{
 			ID:     lifecycleDelete.Id.ValueStringPointer(),
-			Status: &status,
-			Filter: &s3.LifecycleRuleFilter{
+			Status: s3types.ExpirationStatusEnabled,
+			Filter: &s3types.LifecycleRuleFilter{
 				Prefix: lifecycleDelete.Prefix.ValueStringPointer(),
 			},
-			Expiration: &s3.LifecycleExpiration{
-				Days: lib.ConvertInt64ToIntPointer(lifecycleDelete.AfterDays.ValueInt64Pointer()),
+			Expiration: &s3types.LifecycleExpiration{
+				Days: lib.ConvertInt64ToInt32Pointer(lifecycleDelete.AfterDays.ValueInt64Pointer()),
 			},
 		}
-		rules = append(rules, &rule)
+		rules = append(rules, rule)
 	}
 	for _, lifecycleDeleteNonCurrent := range bucket.LifecycleDeleteNonCurrent {
-		rule := s3.LifecycleRule{
+		rule := s3types.LifecycleRule{
 			ID:     lifecycleDeleteNonCurrent.Id.ValueStringPointer(),
-			Status: &status,
-			Filter: &s3.LifecycleRuleFilter{
+			Status: s3types.ExpirationStatusEnabled,
+			Filter: &s3types.LifecycleRuleFilter{
 				Prefix: lifecycleDeleteNonCurrent.Prefix.ValueStringPointer(),
 			},
-			NoncurrentVersionExpiration: &s3.NoncurrentVersionExpiration{
-				NoncurrentDays: lib.ConvertInt64ToIntPointer(lifecycleDeleteNonCurrent.AfterDays.ValueInt64Pointer()),
+			NoncurrentVersionExpiration: &s3types.NoncurrentVersionExpiration{
+				NoncurrentDays: lib.ConvertInt64ToInt32Pointer(lifecycleDeleteNonCurrent.AfterDays.ValueInt64Pointer()),
 			},
 		}
-		rules = append(rules, &rule)
+		rules = append(rules, rule)
 	}
 
-	policy := s3.BucketLifecycleConfiguration{
+	policy := s3types.BucketLifecycleConfiguration{
 		Rules: rules,
 	}
 
 	return policy
 }
 
-func ReadS3LifecyclePolicyRulesIntoBucket(bucket *RgwBucket, rules []*s3.LifecycleRule) {
+func ReadS3LifecyclePolicyRulesIntoBucket(bucket *RgwBucket, rules []s3types.LifecycleRule) {
 	bucket.LifecycleDelete = []RgwLifecycleDelete{}
 
 	for _, rule := range rules {
@@ -147,14 +317,63 @@ func ReadS3LifecyclePolicyRulesIntoBucket(bucket *RgwBucket, rules []*s3.Lifecyc
 		bucket.LifecycleDelete = append(bucket.LifecycleDelete, RgwLifecycleDelete{
 			Id:        types.StringValue(*rule.ID),
 			Prefix:    types.StringValue(prefix),
-			AfterDays: types.Int64Value(*rule.Expiration.Days),
+			AfterDays: types.Int64Value(int64(*rule.Expiration.Days)),
 		})
 	}
 }
 
+// GetRgwBucketStatsDatasourceAttributes returns the computed attributes of
+// RgwBucketStats, apart from the ones shared with RgwBucket.
+func GetRgwBucketStatsDatasourceAttributes(attributes map[string]datasource.Attribute) map[string]datasource.Attribute {
+	for _, name := range []string{"id", "marker", "owner", "zonegroup", "creation_time"} {
+		attributes[name] = datasource.StringAttribute{
+			Computed: true,
+		}
+	}
+
+	attributes["num_shards"] = datasource.Int64Attribute{
+		Computed: true,
+	}
+
+	attributes["usage"] = datasource.SingleNestedAttribute{
+		Computed: true,
+		Attributes: map[string]datasource.Attribute{
+			"size": datasource.Int64Attribute{
+				Computed: true,
+			},
+			"size_actual": datasource.Int64Attribute{
+				Computed: true,
+			},
+			"size_utilized": datasource.Int64Attribute{
+				Computed: true,
+			},
+			"num_objects": datasource.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+
+	attributes["quota"] = datasource.SingleNestedAttribute{
+		Computed: true,
+		Attributes: map[string]datasource.Attribute{
+			"enabled": datasource.BoolAttribute{
+				Computed: true,
+			},
+			"max_size": datasource.Int64Attribute{
+				Computed: true,
+			},
+			"max_objects": datasource.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+
+	return attributes
+}
+
 func GetRgwBucketDatasourceSchema() datasource.Schema {
 	return datasource.Schema{
-		Attributes: map[string]datasource.Attribute{
+		Attributes: GetRgwBucketStatsDatasourceAttributes(map[string]datasource.Attribute{
 			"name": datasource.StringAttribute{
 				Optional: true,
 				Computed: true,
@@ -163,11 +382,18 @@ func GetRgwBucketDatasourceSchema() datasource.Schema {
 				Optional: true,
 				Computed: true,
 			},
+			"storage_class": datasource.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
 			"versioning_enabled": datasource.BoolAttribute{
 				Optional: true,
 				Computed: true,
 			},
-		},
+			"reshard_status": datasource.StringAttribute{
+				Computed: true,
+			},
+		}),
 		Blocks: map[string]datasource.Block{
 			"permission": datasource.ListNestedBlock{
 				NestedObject: datasource.NestedBlockObject{
@@ -228,7 +454,7 @@ func GetRgwBucketDatasourceSchema() datasource.Schema {
 	}
 }
 
-func GetRgwBucketResourceSchema() resource.Schema {
+func GetRgwBucketResourceSchema(ctx context.Context) resource.Schema {
 	return resource.Schema{
 		Attributes: map[string]resource.Attribute{
 			"name": resource.StringAttribute{
@@ -238,11 +464,29 @@ func GetRgwBucketResourceSchema() resource.Schema {
 			"placement_rule": resource.StringAttribute{
 				Optional: true,
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_class": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"versioning_enabled": resource.BoolAttribute{
 				Optional: true,
 				Computed: true,
 			},
+			"num_shards": resource.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 		Blocks: map[string]resource.Block{
 			"permission": resource.ListNestedBlock{
@@ -300,6 +544,12 @@ func GetRgwBucketResourceSchema() resource.Schema {
 					},
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
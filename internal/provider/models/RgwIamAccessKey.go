@@ -0,0 +1,87 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwIamAccessKey struct {
+	User       types.String   `tfsdk:"user"`
+	Status     types.String   `tfsdk:"status"`
+	Id         types.String   `tfsdk:"id"`
+	Secret     types.String   `tfsdk:"secret"`
+	CreateDate types.String   `tfsdk:"create_date"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ToRgwIamAccessKey converts access key metadata. The secret is only returned
+// when the key is created, so the one of prior is kept.
+func ToRgwIamAccessKey(metadata *iam.AccessKeyMetadata, prior RgwIamAccessKey) RgwIamAccessKey {
+	return RgwIamAccessKey{
+		User:       types.StringValue(aws.StringValue(metadata.UserName)),
+		Status:     types.StringValue(aws.StringValue(metadata.Status)),
+		Id:         types.StringValue(aws.StringValue(metadata.AccessKeyId)),
+		Secret:     prior.Secret,
+		CreateDate: types.StringValue(aws.TimeValue(metadata.CreateDate).Format(time.RFC3339)),
+		Timeouts:   prior.Timeouts,
+	}
+}
+
+func GetRgwIamAccessKeyResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"user": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(iam.StatusTypeActive),
+				Validators: []validator.String{
+					stringvalidator.OneOf(iam.StatusType_Values()...),
+				},
+			},
+			"id": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret": resource.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
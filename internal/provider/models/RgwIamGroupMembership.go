@@ -0,0 +1,48 @@
+package models
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RgwIamGroupMembership manages the complete list of users of a group.
+type RgwIamGroupMembership struct {
+	Group    types.String   `tfsdk:"group"`
+	Users    types.Set      `tfsdk:"users"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func GetRgwIamGroupMembershipResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"group": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"users": resource.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwIamUser struct {
+	Name     types.String   `tfsdk:"name"`
+	Path     types.String   `tfsdk:"path"`
+	Arn      types.String   `tfsdk:"arn"`
+	UniqueId types.String   `tfsdk:"unique_id"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func ToRgwIamUser(user *iam.User) RgwIamUser {
+	return RgwIamUser{
+		Name:     types.StringValue(aws.StringValue(user.UserName)),
+		Path:     types.StringValue(aws.StringValue(user.Path)),
+		Arn:      types.StringValue(aws.StringValue(user.Arn)),
+		UniqueId: types.StringValue(aws.StringValue(user.UserId)),
+	}
+}
+
+func GetRgwIamUserResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"name": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("/"),
+			},
+			"arn": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"unique_id": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
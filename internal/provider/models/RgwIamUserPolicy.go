@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwIamUserPolicy struct {
+	User     types.String   `tfsdk:"user"`
+	Name     types.String   `tfsdk:"name"`
+	Policy   types.String   `tfsdk:"policy"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ToRgwIamUserPolicy converts an inline user policy, keeping the policy of
+// prior when it is equivalent to the one returned by RGW.
+func ToRgwIamUserPolicy(output *iam.GetUserPolicyOutput, prior RgwIamUserPolicy) RgwIamUserPolicy {
+	data := prior
+
+	policy := lib.DecodeIamPolicyDocument(aws.StringValue(output.PolicyDocument))
+	if policy != "" && !lib.IamPolicyDocumentsEquivalent(prior.Policy.ValueString(), policy) {
+		data.Policy = types.StringValue(policy)
+	}
+
+	return data
+}
+
+func GetRgwIamUserPolicyResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"user": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy": resource.StringAttribute{
+				Required: true,
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
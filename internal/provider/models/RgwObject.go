@@ -0,0 +1,223 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"terraform-provider-ceph/internal/provider/lib"
+
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwObject struct {
+	Bucket               types.String   `tfsdk:"bucket"`
+	Key                  types.String   `tfsdk:"key"`
+	Content              types.String   `tfsdk:"content"`
+	ContentBase64        types.String   `tfsdk:"content_base64"`
+	Source               types.String   `tfsdk:"source"`
+	ContentType          types.String   `tfsdk:"content_type"`
+	Metadata             types.Map      `tfsdk:"metadata"`
+	Tags                 types.Map      `tfsdk:"tags"`
+	StorageClass         types.String   `tfsdk:"storage_class"`
+	ServerSideEncryption types.String   `tfsdk:"server_side_encryption"`
+	KmsKeyId             types.String   `tfsdk:"kms_key_id"`
+	Etag                 types.String   `tfsdk:"etag"`
+	VersionId            types.String   `tfsdk:"version_id"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+// OpenRgwObjectBody returns a reader over the object content configured
+// through content, content_base64 or source, along with its size.
+func OpenRgwObjectBody(object *RgwObject) (io.ReadSeekCloser, int64, error) {
+	if !object.Source.IsNull() {
+		file, err := os.Open(object.Source.ValueString())
+		if err != nil {
+			return nil, 0, err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, 0, err
+		}
+		return file, info.Size(), nil
+	}
+
+	var content []byte
+	if !object.ContentBase64.IsNull() {
+		decoded, err := base64.StdEncoding.DecodeString(object.ContentBase64.ValueString())
+		if err != nil {
+			return nil, 0, err
+		}
+		content = decoded
+	} else {
+		content = []byte(object.Content.ValueString())
+	}
+
+	return nopReadSeekCloser{bytes.NewReader(content)}, int64(len(content)), nil
+}
+
+// ExpectedRgwObjectEtag computes the ETag RGW will report once the configured
+// content is uploaded. The second return value is false when the ETag cannot
+// be predicted, either because the content is not known yet or because
+// SSE-KMS objects do not expose the content MD5 as their ETag.
+func ExpectedRgwObjectEtag(object *RgwObject) (string, bool, error) {
+	if object.Content.IsUnknown() || object.ContentBase64.IsUnknown() || object.Source.IsUnknown() || object.ServerSideEncryption.IsUnknown() {
+		return "", false, nil
+	}
+	if object.ServerSideEncryption.ValueString() == string(s3types.ServerSideEncryptionAwsKms) {
+		return "", false, nil
+	}
+
+	body, size, err := OpenRgwObjectBody(object)
+	if err != nil {
+		return "", false, err
+	}
+	defer body.Close()
+
+	etag, err := lib.ComputeS3Etag(body, size, lib.S3PartSizeFor(size))
+	if err != nil {
+		return "", false, err
+	}
+
+	return etag, true, nil
+}
+
+func EncodeS3Tagging(tags map[string]string) string {
+	values := url.Values{}
+	for key, value := range tags {
+		values.Set(key, value)
+	}
+	return values.Encode()
+}
+
+func ReadS3MetadataIntoObject(object *RgwObject, metadata map[string]string) {
+	if len(metadata) == 0 && object.Metadata.IsNull() {
+		return
+	}
+
+	values := map[string]string{}
+	for key, value := range metadata {
+		values[strings.ToLower(key)] = value
+	}
+
+	object.Metadata, _ = types.MapValueFrom(context.Background(), types.StringType, values)
+}
+
+func ReadS3TagsIntoObject(object *RgwObject, tagSet []s3types.Tag) {
+	if len(tagSet) == 0 && object.Tags.IsNull() {
+		return
+	}
+
+	values := map[string]string{}
+	for _, tag := range tagSet {
+		if tag.Key != nil && tag.Value != nil {
+			values[*tag.Key] = *tag.Value
+		}
+	}
+
+	object.Tags, _ = types.MapValueFrom(context.Background(), types.StringType, values)
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+func GetRgwObjectResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"bucket": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"content": resource.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("content"),
+						path.MatchRoot("content_base64"),
+						path.MatchRoot("source"),
+					),
+				},
+			},
+			"content_base64": resource.StringAttribute{
+				Optional: true,
+			},
+			"source": resource.StringAttribute{
+				Optional: true,
+			},
+			"content_type": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": resource.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z0-9_.-]+$`), "metadata keys must be lowercase"),
+					),
+				},
+			},
+			"tags": resource.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"storage_class": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"server_side_encryption": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(s3types.ServerSideEncryptionAes256), string(s3types.ServerSideEncryptionAwsKms)),
+				},
+			},
+			"kms_key_id": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"etag": resource.StringAttribute{
+				Computed: true,
+			},
+			"version_id": resource.StringAttribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwOpenIdConnectProvider struct {
+	Url            types.String   `tfsdk:"url"`
+	ClientIdList   types.Set      `tfsdk:"client_id_list"`
+	ThumbprintList types.Set      `tfsdk:"thumbprint_list"`
+	Arn            types.String   `tfsdk:"arn"`
+	CreateDate     types.String   `tfsdk:"create_date"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ToRgwOpenIdConnectProvider converts an OIDC provider. The url of prior is
+// kept when it only differs by its scheme, which IAM strips.
+func ToRgwOpenIdConnectProvider(arn string, output *iam.GetOpenIDConnectProviderOutput, prior RgwOpenIdConnectProvider) RgwOpenIdConnectProvider {
+	clientIds, _ := types.SetValueFrom(context.Background(), types.StringType, aws.StringValueSlice(output.ClientIDList))
+	thumbprints, _ := types.SetValueFrom(context.Background(), types.StringType, aws.StringValueSlice(output.ThumbprintList))
+
+	data := RgwOpenIdConnectProvider{
+		Url:            types.StringValue(aws.StringValue(output.Url)),
+		ClientIdList:   clientIds,
+		ThumbprintList: thumbprints,
+		Arn:            types.StringValue(arn),
+		CreateDate:     types.StringValue(aws.TimeValue(output.CreateDate).Format(time.RFC3339)),
+		Timeouts:       prior.Timeouts,
+	}
+
+	if strings.TrimPrefix(prior.Url.ValueString(), "https://") == strings.TrimPrefix(data.Url.ValueString(), "https://") {
+		data.Url = prior.Url
+	}
+
+	return data
+}
+
+func GetRgwOpenIdConnectProviderResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"url": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"client_id_list": resource.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"thumbprint_list": resource.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeBetween(1, 5),
+					setvalidator.ValueStringsAre(stringvalidator.LengthBetween(40, 40)),
+				},
+			},
+			"arn": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: false,
+				Delete: true,
+			}),
+		},
+	}
+}
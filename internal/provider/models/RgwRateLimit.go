@@ -0,0 +1,166 @@
+package models
+
+import (
+	"context"
+	"terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwUserRateLimit struct {
+	UserId        types.String   `tfsdk:"user_id"`
+	MaxReadOps    types.Int64    `tfsdk:"max_read_ops"`
+	MaxWriteOps   types.Int64    `tfsdk:"max_write_ops"`
+	MaxReadBytes  types.Int64    `tfsdk:"max_read_bytes"`
+	MaxWriteBytes types.Int64    `tfsdk:"max_write_bytes"`
+	Enabled       types.Bool     `tfsdk:"enabled"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+type RgwBucketRateLimit struct {
+	Bucket        types.String   `tfsdk:"bucket"`
+	MaxReadOps    types.Int64    `tfsdk:"max_read_ops"`
+	MaxWriteOps   types.Int64    `tfsdk:"max_write_ops"`
+	MaxReadBytes  types.Int64    `tfsdk:"max_read_bytes"`
+	MaxWriteBytes types.Int64    `tfsdk:"max_write_bytes"`
+	Enabled       types.Bool     `tfsdk:"enabled"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+type RgwGlobalRateLimit struct {
+	Scope         types.String   `tfsdk:"scope"`
+	MaxReadOps    types.Int64    `tfsdk:"max_read_ops"`
+	MaxWriteOps   types.Int64    `tfsdk:"max_write_ops"`
+	MaxReadBytes  types.Int64    `tfsdk:"max_read_bytes"`
+	MaxWriteBytes types.Int64    `tfsdk:"max_write_bytes"`
+	Enabled       types.Bool     `tfsdk:"enabled"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func ToRgwUserRateLimit(uid string, limit lib.RgwRateLimit) RgwUserRateLimit {
+	return RgwUserRateLimit{
+		UserId:        types.StringValue(uid),
+		MaxReadOps:    types.Int64Value(limit.MaxReadOps),
+		MaxWriteOps:   types.Int64Value(limit.MaxWriteOps),
+		MaxReadBytes:  types.Int64Value(limit.MaxReadBytes),
+		MaxWriteBytes: types.Int64Value(limit.MaxWriteBytes),
+		Enabled:       types.BoolValue(limit.Enabled),
+	}
+}
+
+func (data RgwUserRateLimit) RateLimit() lib.RgwRateLimit {
+	return lib.RgwRateLimit{
+		MaxReadOps:    data.MaxReadOps.ValueInt64(),
+		MaxWriteOps:   data.MaxWriteOps.ValueInt64(),
+		MaxReadBytes:  data.MaxReadBytes.ValueInt64(),
+		MaxWriteBytes: data.MaxWriteBytes.ValueInt64(),
+		Enabled:       data.Enabled.ValueBool(),
+	}
+}
+
+func ToRgwBucketRateLimit(bucket string, limit lib.RgwRateLimit) RgwBucketRateLimit {
+	return RgwBucketRateLimit{
+		Bucket:        types.StringValue(bucket),
+		MaxReadOps:    types.Int64Value(limit.MaxReadOps),
+		MaxWriteOps:   types.Int64Value(limit.MaxWriteOps),
+		MaxReadBytes:  types.Int64Value(limit.MaxReadBytes),
+		MaxWriteBytes: types.Int64Value(limit.MaxWriteBytes),
+		Enabled:       types.BoolValue(limit.Enabled),
+	}
+}
+
+func (data RgwBucketRateLimit) RateLimit() lib.RgwRateLimit {
+	return lib.RgwRateLimit{
+		MaxReadOps:    data.MaxReadOps.ValueInt64(),
+		MaxWriteOps:   data.MaxWriteOps.ValueInt64(),
+		MaxReadBytes:  data.MaxReadBytes.ValueInt64(),
+		MaxWriteBytes: data.MaxWriteBytes.ValueInt64(),
+		Enabled:       data.Enabled.ValueBool(),
+	}
+}
+
+func ToRgwGlobalRateLimit(scope string, limit lib.RgwRateLimit) RgwGlobalRateLimit {
+	return RgwGlobalRateLimit{
+		Scope:         types.StringValue(scope),
+		MaxReadOps:    types.Int64Value(limit.MaxReadOps),
+		MaxWriteOps:   types.Int64Value(limit.MaxWriteOps),
+		MaxReadBytes:  types.Int64Value(limit.MaxReadBytes),
+		MaxWriteBytes: types.Int64Value(limit.MaxWriteBytes),
+		Enabled:       types.BoolValue(limit.Enabled),
+	}
+}
+
+func (data RgwGlobalRateLimit) RateLimit() lib.RgwRateLimit {
+	return lib.RgwRateLimit{
+		MaxReadOps:    data.MaxReadOps.ValueInt64(),
+		MaxWriteOps:   data.MaxWriteOps.ValueInt64(),
+		MaxReadBytes:  data.MaxReadBytes.ValueInt64(),
+		MaxWriteBytes: data.MaxWriteBytes.ValueInt64(),
+		Enabled:       data.Enabled.ValueBool(),
+	}
+}
+
+func GetRgwUserRateLimitResourceSchema(ctx context.Context) resource.Schema {
+	return getRgwRateLimitResourceSchema(ctx, "user_id", nil)
+}
+
+func GetRgwBucketRateLimitResourceSchema(ctx context.Context) resource.Schema {
+	return getRgwRateLimitResourceSchema(ctx, "bucket", nil)
+}
+
+func GetRgwGlobalRateLimitResourceSchema(ctx context.Context) resource.Schema {
+	return getRgwRateLimitResourceSchema(ctx, "scope", []validator.String{
+		stringvalidator.OneOf(lib.RgwRateLimitScopeUser, lib.RgwRateLimitScopeBucket, lib.RgwRateLimitScopeAnonymous),
+	})
+}
+
+// getRgwRateLimitResourceSchema returns the schema shared by the rate limit
+// resources, which only differ by the attribute naming what is limited.
+func getRgwRateLimitResourceSchema(ctx context.Context, key string, keyValidators []validator.String) resource.Schema {
+	attributes := map[string]resource.Attribute{
+		key: resource.StringAttribute{
+			Required: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: keyValidators,
+		},
+		"enabled": resource.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(true),
+		},
+	}
+
+	for _, name := range []string{"max_read_ops", "max_write_ops", "max_read_bytes", "max_write_bytes"} {
+		attributes[name] = resource.Int64Attribute{
+			Optional: true,
+			Computed: true,
+			Default:  int64default.StaticInt64(0),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		}
+	}
+
+	return resource.Schema{
+		Attributes: attributes,
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
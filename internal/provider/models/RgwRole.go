@@ -0,0 +1,112 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwRole struct {
+	Name               types.String   `tfsdk:"name"`
+	Path               types.String   `tfsdk:"path"`
+	AssumeRolePolicy   types.String   `tfsdk:"assume_role_policy"`
+	MaxSessionDuration types.Int64    `tfsdk:"max_session_duration"`
+	Arn                types.String   `tfsdk:"arn"`
+	UniqueId           types.String   `tfsdk:"unique_id"`
+	CreateDate         types.String   `tfsdk:"create_date"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ToRgwRole converts an IAM role. The assume role policy of prior is kept when
+// it is equivalent to the one returned by RGW, which reformats documents.
+func ToRgwRole(role *iam.Role, prior RgwRole) RgwRole {
+	assumeRolePolicy := lib.DecodeIamPolicyDocument(aws.StringValue(role.AssumeRolePolicyDocument))
+
+	data := RgwRole{
+		Name:               types.StringValue(aws.StringValue(role.RoleName)),
+		Path:               types.StringValue(aws.StringValue(role.Path)),
+		AssumeRolePolicy:   types.StringValue(assumeRolePolicy),
+		MaxSessionDuration: types.Int64Value(aws.Int64Value(role.MaxSessionDuration)),
+		Arn:                types.StringValue(aws.StringValue(role.Arn)),
+		UniqueId:           types.StringValue(aws.StringValue(role.RoleId)),
+		CreateDate:         types.StringValue(aws.TimeValue(role.CreateDate).Format(time.RFC3339)),
+		Timeouts:           prior.Timeouts,
+	}
+
+	if lib.IamPolicyDocumentsEquivalent(prior.AssumeRolePolicy.ValueString(), assumeRolePolicy) {
+		data.AssumeRolePolicy = prior.AssumeRolePolicy
+	}
+
+	return data
+}
+
+func GetRgwRoleResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"name": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("/"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"assume_role_policy": resource.StringAttribute{
+				Required: true,
+			},
+			"max_session_duration": resource.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(3600),
+				Validators: []validator.Int64{
+					int64validator.Between(3600, 43200),
+				},
+			},
+			"arn": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"unique_id": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": resource.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"context"
+	"terraform-provider-ceph/internal/provider/lib"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RgwRolePolicy struct {
+	Role     types.String   `tfsdk:"role"`
+	Name     types.String   `tfsdk:"name"`
+	Policy   types.String   `tfsdk:"policy"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ToRgwRolePolicy converts an inline role policy, keeping the policy of prior
+// when it is equivalent to the one returned by RGW. Releases before Squid
+// return the document as Permission_policy, which the SDK does not decode,
+// so the prior policy is kept as well when the document is missing.
+func ToRgwRolePolicy(output *iam.GetRolePolicyOutput, prior RgwRolePolicy) RgwRolePolicy {
+	data := prior
+
+	policy := lib.DecodeIamPolicyDocument(aws.StringValue(output.PolicyDocument))
+	if policy != "" && !lib.IamPolicyDocumentsEquivalent(prior.Policy.ValueString(), policy) {
+		data.Policy = types.StringValue(policy)
+	}
+
+	return data
+}
+
+func GetRgwRolePolicyResourceSchema(ctx context.Context) resource.Schema {
+	return resource.Schema{
+		Attributes: map[string]resource.Attribute{
+			"role": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": resource.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy": resource.StringAttribute{
+				Required: true,
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
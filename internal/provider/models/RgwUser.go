@@ -1,21 +1,41 @@
 package models
 
 import (
+	"context"
+	"terraform-provider-ceph/internal/provider/lib"
+
 	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	datasource "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	resource "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type RgwUser struct {
-	Id         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	MaxBuckets types.Int32  `tfsdk:"max_buckets"`
-	AccessKey  types.String `tfsdk:"access_key"`
-	SecretKey  types.String `tfsdk:"secret_key"`
+	Id          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	MaxBuckets  types.Int32    `tfsdk:"max_buckets"`
+	AccessKey   types.String   `tfsdk:"access_key"`
+	SecretKey   types.String   `tfsdk:"secret_key"`
+	AccountId   types.String   `tfsdk:"account_id"`
+	AccountRoot types.Bool     `tfsdk:"account_root"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+type RgwUserDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	MaxBuckets  types.Int32  `tfsdk:"max_buckets"`
+	AccessKey   types.String `tfsdk:"access_key"`
+	SecretKey   types.String `tfsdk:"secret_key"`
+	AccountId   types.String `tfsdk:"account_id"`
+	AccountRoot types.Bool   `tfsdk:"account_root"`
 }
 
-func ToRgwUser(user admin.User) RgwUser {
+func ToRgwUser(user admin.User, account lib.RgwUserAccount) RgwUser {
 
 	accessKey := ""
 	secretKey := ""
@@ -26,11 +46,25 @@ func ToRgwUser(user admin.User) RgwUser {
 	}
 
 	return RgwUser{
-		Id:         types.StringValue(user.ID),
-		Name:       types.StringValue(user.DisplayName),
-		MaxBuckets: types.Int32Value(int32(*user.MaxBuckets)),
-		AccessKey:  types.StringValue(accessKey),
-		SecretKey:  types.StringValue(secretKey),
+		Id:          types.StringValue(user.ID),
+		Name:        types.StringValue(user.DisplayName),
+		MaxBuckets:  types.Int32Value(int32(*user.MaxBuckets)),
+		AccessKey:   types.StringValue(accessKey),
+		SecretKey:   types.StringValue(secretKey),
+		AccountId:   types.StringValue(account.AccountId),
+		AccountRoot: types.BoolValue(account.IsRoot()),
+	}
+}
+
+func ToRgwUserDataSourceModel(data RgwUser) RgwUserDataSourceModel {
+	return RgwUserDataSourceModel{
+		Id:          data.Id,
+		Name:        data.Name,
+		MaxBuckets:  data.MaxBuckets,
+		AccessKey:   data.AccessKey,
+		SecretKey:   data.SecretKey,
+		AccountId:   data.AccountId,
+		AccountRoot: data.AccountRoot,
 	}
 }
 
@@ -59,11 +93,17 @@ func GetRgwUserDatasourceSchema() datasource.Schema {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"account_id": datasource.StringAttribute{
+				Computed: true,
+			},
+			"account_root": datasource.BoolAttribute{
+				Computed: true,
+			},
 		},
 	}
 }
 
-func GetRgwUserResourceSchema() resource.Schema {
+func GetRgwUserResourceSchema(ctx context.Context) resource.Schema {
 	return resource.Schema{
 		Attributes: map[string]resource.Attribute{
 			"id": resource.StringAttribute{
@@ -88,6 +128,30 @@ func GetRgwUserResourceSchema() resource.Schema {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"account_id": resource.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					// Users can join an account but cannot leave it
+					stringplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = req.StateValue.ValueString() != ""
+					}, "", ""),
+				},
+			},
+			"account_root": resource.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]resource.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
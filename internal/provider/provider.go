@@ -2,25 +2,42 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"terraform-provider-ceph/internal/provider/datasources"
 	"terraform-provider-ceph/internal/provider/lib"
 	"terraform-provider-ceph/internal/provider/resources"
 
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Temporary credentials are refreshed this long before they expire, so that
+// requests signed right before the expiry do not fail.
+const assumeRoleExpiryWindow = time.Minute
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ provider.Provider = &CephProvider{}
@@ -44,10 +61,36 @@ type CephProvider struct {
 }
 
 type CephProviderModel struct {
-	Endpoint  types.String `tfsdk:"endpoint"`
-	AccessKey types.String `tfsdk:"access_key"`
-	SecretKey types.String `tfsdk:"secret_key"`
-	Zone      types.String `tfsdk:"zone"`
+	Endpoint                  types.String                 `tfsdk:"endpoint"`
+	Endpoints                 types.List                   `tfsdk:"endpoints"`
+	AdminEndpoint             types.String                 `tfsdk:"admin_endpoint"`
+	S3Endpoint                types.String                 `tfsdk:"s3_endpoint"`
+	AdminPathPrefix           types.String                 `tfsdk:"admin_path_prefix"`
+	AccessKey                 types.String                 `tfsdk:"access_key"`
+	SecretKey                 types.String                 `tfsdk:"secret_key"`
+	CredentialsFile           types.String                 `tfsdk:"credentials_file"`
+	Profile                   types.String                 `tfsdk:"profile"`
+	SharedCredentialsFiles    types.List                   `tfsdk:"shared_credentials_files"`
+	Zone                      types.String                 `tfsdk:"zone"`
+	SkipCredentialsValidation types.Bool                   `tfsdk:"skip_credentials_validation"`
+	MaxRetries                types.Int64                  `tfsdk:"max_retries"`
+	RetryMaxBackoff           types.String                 `tfsdk:"retry_max_backoff"`
+	CACertFile                types.String                 `tfsdk:"ca_cert_file"`
+	CACertPEM                 types.String                 `tfsdk:"ca_cert_pem"`
+	ClientCert                types.String                 `tfsdk:"client_cert"`
+	ClientKey                 types.String                 `tfsdk:"client_key"`
+	Insecure                  types.Bool                   `tfsdk:"insecure_skip_verify"`
+	HTTPProxy                 types.String                 `tfsdk:"http_proxy"`
+	NoProxy                   types.String                 `tfsdk:"no_proxy"`
+	AssumeRole                *CephProviderAssumeRoleModel `tfsdk:"assume_role"`
+}
+
+type CephProviderAssumeRoleModel struct {
+	RoleArn              types.String `tfsdk:"role_arn"`
+	SessionName          types.String `tfsdk:"session_name"`
+	Duration             types.String `tfsdk:"duration"`
+	Policy               types.String `tfsdk:"policy"`
+	WebIdentityTokenFile types.String `tfsdk:"web_identity_token_file"`
 }
 
 // Metadata returns the provider type name.
@@ -59,10 +102,38 @@ func (p *CephProvider) Metadata(_ context.Context, _ provider.MetadataRequest, r
 // Schema defines the provider-level schema for configuration data.
 func (p *CephProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "The access and secret keys are read from the first of these sources that is set:\n\n" +
+			"1. `access_key` and `secret_key`, each falling back to the `CEPH_RGW_ACCESS_KEY` and `CEPH_RGW_SECRET_KEY` " +
+			"environment variables when unset, so that for instance only the secret key is passed in the environment\n" +
+			"2. `credentials_file` or `CEPH_RGW_CREDENTIALS_FILE`, a JSON file with `access_key` and `secret_key` " +
+			"(or the output of `radosgw-admin user info`), or an INI file with `access_key` and `secret_key` " +
+			"(or `aws_access_key_id` and `aws_secret_access_key`)\n" +
+			"3. `profile` or `CEPH_RGW_PROFILE` (`default` when unset) in the first of `shared_credentials_files` " +
+			"or `CEPH_RGW_SHARED_CREDENTIALS_FILES` (`~/.aws/credentials` when unset) that has it. " +
+			"This source is only used when either is set.\n\n" +
+			"A source that is set but cannot be read fails the configuration, rather than falling back to the next one. " +
+			"The source in use is logged at the INFO level, and named in the error reported when the gateway rejects the credentials.",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
 				Optional: true,
 			},
+			"endpoints": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("endpoint")),
+				},
+			},
+			"admin_endpoint": schema.StringAttribute{
+				Optional: true,
+			},
+			"s3_endpoint": schema.StringAttribute{
+				Optional: true,
+			},
+			"admin_path_prefix": schema.StringAttribute{
+				Optional: true,
+			},
 			"access_key": schema.StringAttribute{
 				Optional: true,
 			},
@@ -70,9 +141,74 @@ func (p *CephProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:  true,
 				Sensitive: true,
 			},
+			"credentials_file": schema.StringAttribute{
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				Optional: true,
+			},
+			"shared_credentials_files": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 			"zone": schema.StringAttribute{
 				Optional: true,
 			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional: true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional: true,
+			},
+			"client_cert": schema.StringAttribute{
+				Optional: true,
+			},
+			"client_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional: true,
+			},
+			"http_proxy": schema.StringAttribute{
+				Optional: true,
+			},
+			"no_proxy": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"assume_role": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						Optional: true,
+					},
+					"session_name": schema.StringAttribute{
+						Optional: true,
+					},
+					"duration": schema.StringAttribute{
+						Optional: true,
+					},
+					"policy": schema.StringAttribute{
+						Optional: true,
+					},
+					"web_identity_token_file": schema.StringAttribute{
+						Optional: true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -98,6 +234,14 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	if config.Endpoints.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"Unknown Ceph RGW Endpoints",
+			"The provider cannot create the Ceph RGW client as there is an unknown configuration value for the Ceph RGW endpoints",
+		)
+	}
+
 	if config.AccessKey.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("access_key"),
@@ -114,7 +258,7 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
-	if config.SecretKey.IsUnknown() {
+	if config.Zone.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("zone"),
 			"Unknown Ceph RGW Zone",
@@ -130,69 +274,277 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	// with Terraform configuration value if set.
 
 	endpoint := os.Getenv("CEPH_RGW_ENDPOINT")
-	accessKey := os.Getenv("CEPH_RGW_ACCESS_KEY")
-	secretKey := os.Getenv("CEPH_RGW_SECRET_KEY")
+	var endpoints []string
+	if value := os.Getenv("CEPH_RGW_ENDPOINTS"); value != "" {
+		endpoints = strings.Split(value, ",")
+	}
+	credentialsFile := os.Getenv("CEPH_RGW_CREDENTIALS_FILE")
+	profile := os.Getenv("CEPH_RGW_PROFILE")
+	var sharedCredentialsFiles []string
+	if value := os.Getenv("CEPH_RGW_SHARED_CREDENTIALS_FILES"); value != "" {
+		sharedCredentialsFiles = strings.Split(value, ",")
+	}
+	adminEndpoint := os.Getenv("CEPH_RGW_ADMIN_ENDPOINT")
+	s3Endpoint := os.Getenv("CEPH_RGW_S3_ENDPOINT")
 	zone := os.Getenv("CEPH_RGW_ZONE")
 
 	if !config.Endpoint.IsNull() {
 		endpoint = config.Endpoint.ValueString()
 	}
 
-	if !config.AccessKey.IsNull() {
-		accessKey = config.AccessKey.ValueString()
+	if !config.Endpoints.IsNull() {
+		endpoints = nil
+		resp.Diagnostics.Append(config.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	}
+
+	if !config.AdminEndpoint.IsNull() {
+		adminEndpoint = config.AdminEndpoint.ValueString()
+	}
+
+	if !config.S3Endpoint.IsNull() {
+		s3Endpoint = config.S3Endpoint.ValueString()
+	}
+
+	if !config.CredentialsFile.IsNull() {
+		credentialsFile = config.CredentialsFile.ValueString()
+	}
+
+	if !config.Profile.IsNull() {
+		profile = config.Profile.ValueString()
 	}
 
-	if !config.SecretKey.IsNull() {
-		secretKey = config.SecretKey.ValueString()
+	if !config.SharedCredentialsFiles.IsNull() {
+		sharedCredentialsFiles = nil
+		resp.Diagnostics.Append(config.SharedCredentialsFiles.ElementsAs(ctx, &sharedCredentialsFiles, false)...)
 	}
 
 	if !config.Zone.IsNull() {
 		zone = config.Zone.ValueString()
 	}
 
+	// Calls fail over between the listed gateways. The admin API and S3 may
+	// be served by different gateways, and default to the shared endpoints.
+	if len(endpoints) == 0 && endpoint != "" {
+		endpoints = []string{endpoint}
+	}
+
+	adminEndpoints := endpoints
+	if adminEndpoint != "" {
+		adminEndpoints = []string{adminEndpoint}
+	}
+
+	s3Endpoints := endpoints
+	if s3Endpoint != "" {
+		s3Endpoints = []string{s3Endpoint}
+	}
+
+	adminPathPrefix := "admin"
+	if !config.AdminPathPrefix.IsNull() && config.AdminPathPrefix.ValueString() != "" {
+		adminPathPrefix = config.AdminPathPrefix.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if endpoint == "" {
+	if len(adminEndpoints) == 0 || len(s3Endpoints) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("endpoint"),
 			"Missing Ceph RGW Endpoint",
 			"The provider cannot create the Ceph RGW client as there is a missing or empty value for the Ceph RGW endpoint. "+
-				"Set the endpoint value in the configuration or use the CEPH_RGW_ENDPOINT environment variable. "+
+				"Set the endpoint or endpoints value in the configuration or use the CEPH_RGW_ENDPOINT or CEPH_RGW_ENDPOINTS environment variable, "+
+				"or set both admin_endpoint and s3_endpoint. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if accessKey == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("access_key"),
-			"Missing Ceph RGW Access Key",
-			"The provider cannot create the Ceph RGW client as there is a missing or empty value for the Ceph RGW access_key. "+
-				"Set the access_key value in the configuration or use the CEPH_RGW_ACCESS_KEY environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+	// Web identity federation exchanges a token for credentials, without
+	// long-lived keys
+	useWebIdentity := config.AssumeRole != nil && config.AssumeRole.WebIdentityTokenFile.ValueString() != ""
+
+	// The keys are read from the first configured source, in the order
+	// documented on the provider
+	staticCredentials, credentialsSource, err := lib.ResolveRgwCredentials([]lib.RgwCredentialSource{
+		lib.RgwConfigCredentialSource(config.AccessKey.ValueString(), config.SecretKey.ValueString()),
+		lib.RgwCredentialsFileSource("credentials_file", credentialsFile),
+		lib.RgwSharedCredentialSource("profile and shared_credentials_files", sharedCredentialsFiles, profile),
+	})
+	switch {
+	case errors.Is(err, lib.ErrRgwCredentialSourceNotSet):
+		if !useWebIdentity {
+			resp.Diagnostics.AddError(
+				"Missing Ceph RGW Credentials",
+				"The provider cannot create the Ceph RGW client as no credentials were found. "+
+					"Set access_key and secret_key in the configuration, use the CEPH_RGW_ACCESS_KEY and CEPH_RGW_SECRET_KEY environment variables, "+
+					"or point credentials_file, profile or shared_credentials_files to the keys.\n\n"+
+					"Error: "+err.Error(),
+			)
+		}
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Invalid Ceph RGW Credentials",
+			"The provider cannot create the Ceph RGW client as the credentials could not be read from "+credentialsSource+".\n\n"+
+				"Error: "+err.Error(),
 		)
+	default:
+		tflog.Info(ctx, "Using Ceph RGW credentials", map[string]interface{}{
+			"source":     credentialsSource,
+			"access_key": staticCredentials.AccessKeyID,
+		})
 	}
 
-	if secretKey == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("secret_key"),
-			"Missing Ceph RGW Secret Key",
-			"The provider cannot create the Ceph RGW client as there is a missing or empty value for the Ceph RGW secret_key. "+
-				"Set the secret_key value in the configuration or use the CEPH_RGW_SECRET_KEY environment variable. "+
-				"If either is already set, ensure the value is not empty.",
-		)
+	// Named in the diagnostics of calls the gateway rejects, as there is no
+	// informational diagnostic to report the source with
+	credentialsOrigin := credentialsSource
+	if useWebIdentity {
+		credentialsOrigin = "web_identity_token_file"
+	}
+	if config.AssumeRole != nil {
+		credentialsOrigin = "role " + config.AssumeRole.RoleArn.ValueString() + " assumed with " + credentialsOrigin
 	}
 
 	if zone == "" {
 		zone = "default"
 	}
 
+	maxRetries := lib.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	retryMaxBackoff := lib.DefaultRetryMaxBackoff
+	if !config.RetryMaxBackoff.IsNull() {
+		parsed, err := time.ParseDuration(config.RetryMaxBackoff.ValueString())
+		if err != nil || parsed <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid Ceph RGW Retry Backoff",
+				"The retry_max_backoff must be a positive duration such as \"30s\" or \"2m\".",
+			)
+		}
+		retryMaxBackoff = parsed
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Create a new client using the configuration values
-	rgwClient, err := admin.New(endpoint, accessKey, secretKey, nil)
+	httpClient, err := lib.NewRgwHTTPClient(lib.RgwHTTPClientConfig{
+		TLS: lib.RgwTLSConfig{
+			CACertFile:         config.CACertFile.ValueString(),
+			CACertPEM:          config.CACertPEM.ValueString(),
+			ClientCert:         config.ClientCert.ValueString(),
+			ClientKey:          config.ClientKey.ValueString(),
+			InsecureSkipVerify: config.Insecure.ValueBool(),
+		},
+		HTTPProxy: config.HTTPProxy.ValueString(),
+		NoProxy:   config.NoProxy.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Ceph RGW HTTP Configuration",
+			"The provider cannot create the Ceph RGW client as its TLS or proxy settings are invalid.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	adminEndpointPool, err := lib.NewRgwEndpointPool(adminEndpoints)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Ceph RGW Endpoint",
+			"The provider cannot create the Ceph RGW client as an admin endpoint is invalid.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	s3EndpointPool := adminEndpointPool
+	if !slices.Equal(s3Endpoints, adminEndpoints) {
+		s3EndpointPool, err = lib.NewRgwEndpointPool(s3Endpoints)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Ceph RGW Endpoint",
+				"The provider cannot create the Ceph RGW client as an S3 endpoint is invalid.\n\n"+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// A single endpoint is not probed, so that planning does not depend on
+	// the gateway being reachable
+	for _, pool := range []*lib.RgwEndpointPool{adminEndpointPool, s3EndpointPool} {
+		if pool.Len() < 2 {
+			continue
+		}
+		if err := pool.Probe(ctx, httpClient); err != nil {
+			resp.Diagnostics.AddError(
+				"No Healthy Ceph RGW Endpoint",
+				"The provider cannot create the Ceph RGW client as none of the configured endpoints is healthy.\n\n"+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	awsConfig := aws.NewConfig().
+		WithRegion(zone).
+		WithEndpoint(s3EndpointPool.Current().String()).
+		WithCredentials(credentials.NewStaticCredentialsFromCreds(staticCredentials)).
+		WithHTTPClient(lib.NewRgwLoggingHTTPClient(httpClient, lib.LogSubsystemS3))
+
+	// Failing over goes through the retryer, which must then try every
+	// endpoint
+	awsConfig = request.WithRetryer(awsConfig, lib.RgwRetryer{
+		NumMaxRetries: max(maxRetries, s3EndpointPool.Len()-1),
+		MaxBackoff:    retryMaxBackoff,
+	})
+
+	awsSession := session.Must(session.NewSession())
+	s3EndpointPool.InstallHandlers(&awsSession.Handlers)
+
+	if config.AssumeRole != nil {
+		creds, diags := assumeRoleCredentials(sts.New(awsSession, awsConfig.Copy()), config.AssumeRole)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		awsConfig.WithCredentials(creds)
+	}
+
+	// Retrieving the credentials once assumes the role, so that a role that
+	// cannot be assumed fails here rather than in the first resource
+	credentialsValue, err := awsConfig.Credentials.GetWithContext(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Retrieve Ceph RGW Credentials",
+			"An unexpected error occurred when retrieving the credentials of the Ceph RGW client. "+
+				"If an assume_role block is configured, ensure the role can be assumed.\n\n"+
+				"Credentials: "+credentialsOrigin+"\n"+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	adminHTTPClient := lib.NewRgwLoggingHTTPClient(httpClient, lib.LogSubsystemAdmin)
+
+	// Create a new client using the configuration values. Requests are signed
+	// again with the provider credentials, which may be refreshed during the
+	// apply.
+	rgwClient, err := admin.New(adminEndpointPool.Current().String(), credentialsValue.AccessKeyID, credentialsValue.SecretAccessKey, &lib.RgwRetryingHTTPClient{
+		HTTPClient: &lib.RgwFailoverHTTPClient{
+			Endpoints: adminEndpointPool,
+			HTTPClient: &lib.RgwAdminPathHTTPClient{
+				AdminPath: adminPathPrefix,
+				HTTPClient: &lib.RgwSigningHTTPClient{
+					Credentials: awsConfig.Credentials,
+					HTTPClient:  adminHTTPClient,
+				},
+			},
+		},
+		MaxRetries: maxRetries,
+		MaxBackoff: retryMaxBackoff,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Ceph RGW Client",
@@ -203,19 +555,47 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	s3Session := session.Must(session.NewSession())
-	s3Client := s3.New(
-		s3Session,
-		aws.NewConfig().
-			WithRegion(zone).
-			WithEndpoint(endpoint).
-			WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, "")).
-			WithS3ForcePathStyle(true),
-	)
+	s3Client := lib.NewRgwS3Client(lib.RgwS3ClientConfig{
+		Region:      zone,
+		Endpoints:   s3EndpointPool,
+		Credentials: awsConfig.Credentials,
+		HTTPClient:  lib.NewRgwLoggingHTTPClient(httpClient, lib.LogSubsystemS3),
+		MaxRetries:  maxRetries,
+		MaxBackoff:  retryMaxBackoff,
+	})
+
+	// RGW serves the IAM API on the same endpoint as S3
+	iamClient := iam.New(awsSession, awsConfig.Copy())
+
+	adminClient := lib.NewRgwAdminClient(adminEndpointPool.Current().String(), &lib.RgwRetryingHTTPClient{
+		HTTPClient: &lib.RgwFailoverHTTPClient{
+			Endpoints: adminEndpointPool,
+			HTTPClient: &lib.RgwAdminPathHTTPClient{
+				AdminPath: adminPathPrefix,
+				HTTPClient: &lib.RgwSigningHTTPClient{
+					Credentials: awsConfig.Credentials,
+					HTTPClient:  adminHTTPClient,
+				},
+			},
+		},
+		MaxRetries: maxRetries,
+		MaxBackoff: retryMaxBackoff,
+	})
 
 	clientLibs := &lib.CephProviderClientLibs{
-		S3:  s3Client,
-		Rgw: rgwClient,
+		S3:    s3Client,
+		IAM:   iamClient,
+		Rgw:   rgwClient,
+		Admin: adminClient,
+	}
+
+	// Temporary credentials of an assumed role do not belong to a user that
+	// could be looked up, so only S3 is checked for them
+	if !config.SkipCredentialsValidation.ValueBool() {
+		resp.Diagnostics.Append(validateConnectivity(ctx, clientLibs, credentialsValue.AccessKeyID, credentialsOrigin, config.AssumeRole == nil)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	// Make the client available during DataSource and Resource
@@ -224,12 +604,154 @@ func (p *CephProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	resp.ResourceData = clientLibs
 }
 
+// validateConnectivity lists the buckets and looks up the user owning the
+// credentials, so that misconfigured endpoints and keys are reported here
+// rather than by the first resource.
+func validateConnectivity(ctx context.Context, clientLibs *lib.CephProviderClientLibs, accessKey string, credentialsOrigin string, checkAdmin bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if _, err := clientLibs.S3.ListBuckets(ctx, &s3.ListBucketsInput{}); err != nil {
+		diags.Append(connectivityDiagnostic("S3", credentialsOrigin, err))
+		return diags
+	}
+
+	if !checkAdmin {
+		return diags
+	}
+
+	_, err := clientLibs.Rgw.GetUser(ctx, admin.User{Keys: []admin.UserKeySpec{{AccessKey: accessKey}}})
+	switch {
+	case err == nil:
+	case lib.HasErrorCode(err, string(admin.ErrAccessDenied)):
+		// The keys were accepted by S3, so only the caps are missing
+		diags.AddError(
+			"Missing Ceph RGW Admin Capabilities",
+			"The Ceph RGW credentials are valid, but their user is not allowed to call the admin API. "+
+				"Grant the user the capabilities needed by the managed resources, at least \"users=read\", "+
+				"for example with: radosgw-admin caps add --uid=<user> --caps=\"users=*;buckets=*\"\n\n"+
+				"Credentials: "+credentialsOrigin+"\n"+
+				"Error: "+err.Error(),
+		)
+	default:
+		diags.Append(connectivityDiagnostic("admin API", credentialsOrigin, err))
+	}
+
+	return diags
+}
+
+func connectivityDiagnostic(api string, credentialsOrigin string, err error) diag.Diagnostic {
+	switch {
+	case lib.IsDNSError(err):
+		return diag.NewErrorDiagnostic(
+			"Unable to Resolve Ceph RGW Endpoint",
+			"The host name of the Ceph RGW "+api+" endpoint could not be resolved. "+
+				"Ensure the endpoint is spelled correctly and resolvable from where Terraform runs.\n\n"+
+				"Error: "+err.Error(),
+		)
+	case lib.IsTLSError(err):
+		return diag.NewErrorDiagnostic(
+			"Ceph RGW TLS Handshake Failed",
+			"The TLS connection to the Ceph RGW "+api+" endpoint could not be established. "+
+				"If the gateway certificate is signed by a private CA, set ca_cert_file or ca_cert_pem. "+
+				"If the gateway does not serve TLS, use an http:// endpoint.\n\n"+
+				"Error: "+err.Error(),
+		)
+	case lib.ClassifyError(err) == lib.ErrorClassAuth:
+		return diag.NewErrorDiagnostic(
+			"Invalid Ceph RGW Credentials",
+			"The Ceph RGW "+api+" endpoint rejected the credentials. "+
+				"Ensure the access and secret keys are those of an existing user, "+
+				"and that the clock of the host running Terraform is in sync.\n\n"+
+				"Credentials: "+credentialsOrigin+"\n"+
+				"Error: "+err.Error(),
+		)
+	default:
+		return diag.NewErrorDiagnostic(
+			"Unable to Connect to Ceph RGW",
+			"The provider could not call the Ceph RGW "+api+" endpoint. "+
+				"Ensure the endpoint is correct and the gateway is reachable, "+
+				"or set skip_credentials_validation to configure the provider without calling it.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+// assumeRoleCredentials returns credentials obtained from RGW STS, which are
+// refreshed when they are about to expire.
+func assumeRoleCredentials(stsClient *sts.STS, assumeRole *CephProviderAssumeRoleModel) (*credentials.Credentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if assumeRole.RoleArn.ValueString() == "" {
+		diags.AddAttributeError(
+			path.Root("assume_role").AtName("role_arn"),
+			"Missing Ceph RGW Role ARN",
+			"The provider cannot assume a role without the role_arn of the assume_role block.",
+		)
+	}
+
+	duration := time.Duration(0)
+	if !assumeRole.Duration.IsNull() {
+		parsed, err := time.ParseDuration(assumeRole.Duration.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("assume_role").AtName("duration"),
+				"Invalid Ceph RGW Session Duration",
+				"The duration of the assume_role block must be a duration such as \"1h\" or \"30m\": "+err.Error(),
+			)
+		}
+		duration = parsed
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	sessionName := assumeRole.SessionName.ValueString()
+	if sessionName == "" {
+		sessionName = "terraform-provider-ceph"
+	}
+
+	if tokenFile := assumeRole.WebIdentityTokenFile.ValueString(); tokenFile != "" {
+		provider := stscreds.NewWebIdentityRoleProviderWithOptions(stsClient, assumeRole.RoleArn.ValueString(), sessionName, stscreds.FetchTokenPath(tokenFile))
+		provider.ExpiryWindow = assumeRoleExpiryWindow
+		if duration != 0 {
+			provider.Duration = duration
+		}
+
+		// The web identity provider of the SDK does not pass a session policy
+		if policy := assumeRole.Policy.ValueStringPointer(); policy != nil {
+			stsClient.Handlers.Build.PushFront(func(r *request.Request) {
+				if input, ok := r.Params.(*sts.AssumeRoleWithWebIdentityInput); ok {
+					input.Policy = policy
+				}
+			})
+		}
+
+		return credentials.NewCredentials(provider), diags
+	}
+
+	return credentials.NewCredentials(&stscreds.AssumeRoleProvider{
+		Client:          stsClient,
+		RoleARN:         assumeRole.RoleArn.ValueString(),
+		RoleSessionName: sessionName,
+		Duration:        duration,
+		Policy:          assumeRole.Policy.ValueStringPointer(),
+		ExpiryWindow:    assumeRoleExpiryWindow,
+	}), diags
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *CephProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		datasources.NewRgwBucketsDataSource,
 		datasources.NewRgwBucketDataSource,
 		datasources.NewRgwUserDataSource,
+		datasources.NewRgwObjectDataSource,
+		datasources.NewRgwObjectsDataSource,
+		datasources.NewRgwRealmDataSource,
+		datasources.NewRgwZoneGroupDataSource,
+		datasources.NewRgwZoneDataSource,
+		datasources.NewRgwUsageDataSource,
 	}
 }
 
@@ -238,5 +760,18 @@ func (p *CephProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewRgwBucketResource,
 		resources.NewRgwUserResource,
+		resources.NewRgwObjectResource,
+		resources.NewRgwRoleResource,
+		resources.NewRgwRolePolicyResource,
+		resources.NewRgwOpenIdConnectProviderResource,
+		resources.NewRgwAccountResource,
+		resources.NewRgwIamUserResource,
+		resources.NewRgwIamGroupResource,
+		resources.NewRgwIamGroupMembershipResource,
+		resources.NewRgwIamUserPolicyResource,
+		resources.NewRgwIamAccessKeyResource,
+		resources.NewRgwUserRateLimitResource,
+		resources.NewRgwBucketRateLimitResource,
+		resources.NewRgwGlobalRateLimitResource,
 	}
 }
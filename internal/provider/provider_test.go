@@ -0,0 +1,122 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccZoneDataSourceConfig = `
+data "ceph_rgw_zone" "test" {}
+`
+
+func TestAccProvider_environmentCredentials(t *testing.T) {
+	server := acctest.NewServer(t)
+	t.Setenv("CEPH_RGW_ACCESS_KEY", server.AccessKey)
+	t.Setenv("CEPH_RGW_SECRET_KEY", server.SecretKey)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "ceph" {
+  endpoint = %q
+}
+`, server.URL) + testAccZoneDataSourceConfig,
+				Check: resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "name", "default"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_mixedCredentials(t *testing.T) {
+	server := acctest.NewServer(t)
+	t.Setenv("CEPH_RGW_ACCESS_KEY", "")
+	t.Setenv("CEPH_RGW_SECRET_KEY", server.SecretKey)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "ceph" {
+  endpoint   = %q
+  access_key = %q
+}
+`, server.URL, server.AccessKey) + testAccZoneDataSourceConfig,
+				Check: resource.TestCheckResourceAttr("data.ceph_rgw_zone.test", "name", "default"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_invalidCredentials(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.ProviderConfigWithKeys(server, "UNKNOWNKEY", "secret") + testAccZoneDataSourceConfig,
+				ExpectError: regexp.MustCompile(`(?s)Invalid Ceph RGW Credentials.*Credentials: access_key and secret_key`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_invalidEnvironmentCredentials(t *testing.T) {
+	server := acctest.NewServer(t)
+	t.Setenv("CEPH_RGW_ACCESS_KEY", "UNKNOWNKEY")
+	t.Setenv("CEPH_RGW_SECRET_KEY", "secret")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "ceph" {
+  endpoint    = %q
+  max_retries = 0
+}
+`, server.URL) + testAccZoneDataSourceConfig,
+				ExpectError: regexp.MustCompile(`Credentials: CEPH_RGW_ACCESS_KEY and CEPH_RGW_SECRET_KEY\s+environment\s+variables`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_missingAdminCaps(t *testing.T) {
+	server := acctest.NewServer(t)
+	accessKey, secretKey := server.AddUser("s3-only", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.ProviderConfigWithKeys(server, accessKey, secretKey) + testAccZoneDataSourceConfig,
+				ExpectError: regexp.MustCompile(`Missing Ceph RGW Admin Capabilities`),
+			},
+		},
+	})
+}
+
+func TestAccProvider_unreachableEndpoint(t *testing.T) {
+	server := acctest.NewServer(t)
+	config := acctest.ProviderConfig(server) + testAccZoneDataSourceConfig
+	server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Unable to Connect to Ceph RGW`),
+			},
+		},
+	})
+}
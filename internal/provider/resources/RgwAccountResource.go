@@ -0,0 +1,251 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwAccountResource{}
+	_ resource.ResourceWithConfigure   = &RgwAccountResource{}
+	_ resource.ResourceWithImportState = &RgwAccountResource{}
+)
+
+const (
+	defaultRgwAccountCreateTimeout = 5 * time.Minute
+	defaultRgwAccountReadTimeout   = 5 * time.Minute
+	defaultRgwAccountUpdateTimeout = 5 * time.Minute
+	defaultRgwAccountDeleteTimeout = 5 * time.Minute
+)
+
+type RgwAccountResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwAccountResource() resource.Resource {
+	return &RgwAccountResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_account"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwAccountResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwAccountResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwAccountResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwAccount
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwAccountCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	account, err := r.clientLibs.Admin.CreateAccount(ctx, model.ToRgwAccountSpec(data))
+	if err != nil {
+		resp.Diagnostics.AddError("CreateAccount failed", err.Error())
+		return
+	}
+
+	if data.Quota != nil {
+		err = r.clientLibs.Admin.SetAccountQuota(ctx, account.Id, model.ToRgwAccountQuota(*data.Quota))
+		if err != nil {
+			resp.Diagnostics.AddError("SetAccountQuota failed", err.Error())
+			return
+		}
+
+		account, err = r.clientLibs.Admin.GetAccount(ctx, account.Id)
+		if err != nil {
+			resp.Diagnostics.AddError("GetAccount failed", err.Error())
+			return
+		}
+	}
+
+	data = model.ToRgwAccount(account, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwAccount
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwAccountReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	account, err := r.clientLibs.Admin.GetAccount(ctx, data.Id.ValueString())
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "Account "+data.Id.ValueString()+" not found, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetAccount failed", err.Error())
+		return
+	}
+
+	data = model.ToRgwAccount(account, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state model.RgwAccount
+	var desired model.RgwAccount
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &desired)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := desired.Timeouts.Update(ctx, defaultRgwAccountUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.Admin.ModifyAccount(ctx, model.ToRgwAccountSpec(desired))
+	if err != nil {
+		resp.Diagnostics.AddError("ModifyAccount failed", err.Error())
+		return
+	}
+
+	// Removing the quota from the configuration disables it
+	quota := desired.Quota
+	if quota == nil && state.Quota != nil {
+		quota = &model.RgwAccountQuota{
+			Enabled:    types.BoolValue(false),
+			MaxSize:    types.Int64Value(-1),
+			MaxObjects: types.Int64Value(-1),
+		}
+	}
+
+	if quota != nil {
+		err = r.clientLibs.Admin.SetAccountQuota(ctx, desired.Id.ValueString(), model.ToRgwAccountQuota(*quota))
+		if err != nil {
+			resp.Diagnostics.AddError("SetAccountQuota failed", err.Error())
+			return
+		}
+	}
+
+	account, err := r.clientLibs.Admin.GetAccount(ctx, desired.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("GetAccount failed", err.Error())
+		return
+	}
+
+	desired = model.ToRgwAccount(account, desired)
+
+	// Set state
+	diags = resp.State.Set(ctx, &desired)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwAccount
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwAccountDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.clientLibs.Admin.DeleteAccount(ctx, data.Id.ValueString())
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("DeleteAccount failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,147 @@
+package resources_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRgwAccountResource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			if server.HasAccount("RGW33333333333333333") {
+				return fmt.Errorf("account RGW33333333333333333 still exists")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_account" "test" {
+  id          = "RGW33333333333333333"
+  name        = "analytics"
+  email       = "analytics@example.com"
+  max_buckets = 10
+
+  quota = {
+    max_objects = 1000
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "id", "RGW33333333333333333"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "name", "analytics"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "email", "analytics@example.com"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "max_buckets", "10"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "max_users", "1000"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "quota.enabled", "true"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "quota.max_size", "-1"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "quota.max_objects", "1000"),
+				),
+			},
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_account" "test" {
+  id        = "RGW33333333333333333"
+  name      = "analytics"
+  max_users = 5
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "email", ""),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "max_buckets", "10"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "max_users", "5"),
+					resource.TestCheckNoResourceAttr("ceph_rgw_account.test", "quota.enabled"),
+				),
+			},
+			{
+				ResourceName:      "ceph_rgw_account.test",
+				ImportState:       true,
+				ImportStateId:     "RGW33333333333333333",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRgwAccountResource_generatedId(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_account" "test" {
+  name = "generated"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("ceph_rgw_account.test", "id", regexp.MustCompile(`^RGW[0-9]{17}$`)),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "name", "generated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwAccountResource_user(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_account" "test" {
+  name = "team"
+}
+
+resource "ceph_rgw_user" "root" {
+  id           = "team-root"
+  account_id   = ceph_rgw_account.test.id
+  account_root = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ceph_rgw_user.root", "account_id", "ceph_rgw_account.test", "id"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.root", "account_root", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwAccountResource_timeouts(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_account" "test" {
+  name = "timed"
+
+  timeouts {
+    create = "1m"
+    read   = "30s"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "timeouts.create", "1m"),
+					resource.TestCheckResourceAttr("ceph_rgw_account.test", "timeouts.read", "30s"),
+				),
+			},
+		},
+	})
+}
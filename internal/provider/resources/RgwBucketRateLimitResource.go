@@ -0,0 +1,226 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwBucketRateLimitResource{}
+	_ resource.ResourceWithConfigure   = &RgwBucketRateLimitResource{}
+	_ resource.ResourceWithImportState = &RgwBucketRateLimitResource{}
+)
+
+const (
+	defaultRgwBucketRateLimitCreateTimeout = 5 * time.Minute
+	defaultRgwBucketRateLimitReadTimeout   = 5 * time.Minute
+	defaultRgwBucketRateLimitUpdateTimeout = 5 * time.Minute
+	defaultRgwBucketRateLimitDeleteTimeout = 5 * time.Minute
+)
+
+type RgwBucketRateLimitResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwBucketRateLimitResource() resource.Resource {
+	return &RgwBucketRateLimitResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwBucketRateLimitResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_bucket_ratelimit"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwBucketRateLimitResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwBucketRateLimitResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwBucketRateLimitResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwBucketRateLimitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwBucketRateLimit
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwBucketRateLimitCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.clientLibs.Admin.SetBucketRateLimit(ctx, data.Bucket.ValueString(), data.RateLimit())
+	if err != nil {
+		resp.Diagnostics.AddError("SetBucketRateLimit failed", err.Error())
+		return
+	}
+
+	limit, err := r.clientLibs.Admin.GetBucketRateLimit(ctx, data.Bucket.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("GetBucketRateLimit failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwBucketRateLimit(data.Bucket.ValueString(), limit)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwBucketRateLimitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwBucketRateLimit
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwBucketRateLimitReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	limit, err := r.clientLibs.Admin.GetBucketRateLimit(ctx, data.Bucket.ValueString())
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "Bucket "+data.Bucket.ValueString()+" not found, removing rate limit from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetBucketRateLimit failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwBucketRateLimit(data.Bucket.ValueString(), limit)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwBucketRateLimitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data model.RgwBucketRateLimit
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultRgwBucketRateLimitUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.clientLibs.Admin.SetBucketRateLimit(ctx, data.Bucket.ValueString(), data.RateLimit())
+	if err != nil {
+		resp.Diagnostics.AddError("SetBucketRateLimit failed", err.Error())
+		return
+	}
+
+	limit, err := r.clientLibs.Admin.GetBucketRateLimit(ctx, data.Bucket.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("GetBucketRateLimit failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwBucketRateLimit(data.Bucket.ValueString(), limit)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resets the rate limit, which cannot be removed, to unlimited and
+// disabled.
+func (r *RgwBucketRateLimitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwBucketRateLimit
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwBucketRateLimitDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.clientLibs.Admin.SetBucketRateLimit(ctx, data.Bucket.ValueString(), lib.RgwRateLimit{})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("SetBucketRateLimit failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwBucketRateLimitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	lib "terraform-provider-ceph/internal/provider/lib"
 	model "terraform-provider-ceph/internal/provider/models"
 
-	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/ceph/go-ceph/rgw/admin"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,6 +25,13 @@ var (
 	_ resource.ResourceWithImportState = &RgwBucketResource{}
 )
 
+const (
+	defaultRgwBucketCreateTimeout = 30 * time.Minute
+	defaultRgwBucketReadTimeout   = 5 * time.Minute
+	defaultRgwBucketUpdateTimeout = 30 * time.Minute
+	defaultRgwBucketDeleteTimeout = 10 * time.Minute
+)
+
 type RgwBucketResource struct {
 	clientLibs *lib.CephProviderClientLibs
 }
@@ -37,8 +46,8 @@ func (r *RgwBucketResource) Metadata(_ context.Context, req resource.MetadataReq
 }
 
 // Schema defines the schema for the resource.
-func (r *RgwBucketResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = model.GetRgwBucketResourceSchema()
+func (r *RgwBucketResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwBucketResourceSchema(ctx)
 }
 
 // Configure implements resource.ResourceWithConfigure.
@@ -69,33 +78,49 @@ func (r *RgwBucketResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	var placement = data.PlacementRule.ValueString()
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwBucketCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	bucket := s3.CreateBucketInput{}
-	bucket.SetBucket(data.Name.ValueString())
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	if placement != "" {
-		bucket.CreateBucketConfiguration = &s3.CreateBucketConfiguration{}
+	var planned = data
 
-		bucket.CreateBucketConfiguration.SetLocationConstraint(*r.clientLibs.S3.Config.Region + ":" + placement)
+	placement, err := r.resolvePlacement(ctx, data.PlacementRule.ValueString(), data.StorageClass.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("placement_rule"), "Invalid bucket placement", err.Error())
+		return
 	}
 
-	_, err := r.clientLibs.S3.CreateBucket(&bucket)
+	bucket := s3.CreateBucketInput{
+		Bucket: data.Name.ValueStringPointer(),
+	}
+
+	if placement != "" {
+		bucket.CreateBucketConfiguration = &s3types.CreateBucketConfiguration{
+			LocationConstraint: s3types.BucketLocationConstraint(model.GetRgwBucketLocationConstraint(r.clientLibs.S3.Options().Region, placement, data.StorageClass.ValueString())),
+		}
+	}
+
+	_, err = r.clientLibs.S3.CreateBucket(ctx, &bucket)
 	if err != nil {
 		resp.Diagnostics.AddError("CreateBucket failed", err.Error())
 		return
 	}
 
-	var status string
+	var status s3types.BucketVersioningStatus
 	if data.VersioningEnabled.ValueBool() {
-		status = s3.BucketVersioningStatusEnabled
+		status = s3types.BucketVersioningStatusEnabled
 	} else {
-		status = s3.BucketVersioningStatusSuspended
+		status = s3types.BucketVersioningStatusSuspended
 	}
-	_, err = r.clientLibs.S3.PutBucketVersioning(&s3.PutBucketVersioningInput{
+	_, err = r.clientLibs.S3.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
 		Bucket: data.Name.ValueStringPointer(),
-		VersioningConfiguration: &s3.VersioningConfiguration{
-			Status: &status,
+		VersioningConfiguration: &s3types.VersioningConfiguration{
+			Status: status,
 		},
 	})
 	if err != nil {
@@ -110,7 +135,7 @@ func (r *RgwBucketResource) Create(ctx context.Context, req resource.CreateReque
 			resp.Diagnostics.AddError("Failed to generate bucket policy", err.Error())
 			return
 		}
-		_, err = r.clientLibs.S3.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		_, err = r.clientLibs.S3.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: data.Name.ValueStringPointer(),
 			Policy: &s3BucketPolicyJson,
 		})
@@ -122,7 +147,7 @@ func (r *RgwBucketResource) Create(ctx context.Context, req resource.CreateReque
 
 	if len(data.LifecycleDelete) > 0 {
 		s3LifecyclePolicy := model.GenerateS3LifecyclePolicyFromBucket(&data)
-		_, err = r.clientLibs.S3.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+		_, err = r.clientLibs.S3.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
 			Bucket:                 data.Name.ValueStringPointer(),
 			LifecycleConfiguration: &s3LifecyclePolicy,
 		})
@@ -140,25 +165,29 @@ func (r *RgwBucketResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	data = model.ToRgwBucket(bucketInfo)
+	data.Timeouts = planned.Timeouts
+	model.NormalizeRgwBucketPlacement(&data, planned.PlacementRule, func() string {
+		return r.clientLibs.GetDefaultPlacement(ctx)
+	})
 
-	versioning, err := r.clientLibs.S3.GetBucketVersioning(&s3.GetBucketVersioningInput{
+	versioning, err := r.clientLibs.S3.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 		Bucket: data.Name.ValueStringPointer(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get bucket versioning", err.Error())
 		return
 	}
-	if versioning.Status != nil && *versioning.Status == s3.BucketVersioningStatusEnabled {
+	if versioning.Status == s3types.BucketVersioningStatusEnabled {
 		data.VersioningEnabled = types.BoolValue(true)
 	} else {
 		data.VersioningEnabled = types.BoolValue(false)
 	}
 
 	// Now get bucket policy and set it in the state
-	policyJson, err := r.clientLibs.S3.GetBucketPolicy(&s3.GetBucketPolicyInput{
+	policyJson, err := r.clientLibs.S3.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
 		Bucket: data.Name.ValueStringPointer(),
 	})
-	if err != nil && !strings.HasPrefix(err.Error(), "NoSuchBucketPolicy") {
+	if err != nil && !lib.HasErrorCode(err, lib.ErrCodeNoSuchBucketPolicy) {
 		resp.Diagnostics.AddError("Failed to get bucket policy", err.Error())
 		return
 	}
@@ -174,10 +203,10 @@ func (r *RgwBucketResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Now get bucket lifecycle policy and set it in the state
-	lifecyclePolicy, err := r.clientLibs.S3.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+	lifecyclePolicy, err := r.clientLibs.S3.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
 		Bucket: data.Name.ValueStringPointer(),
 	})
-	if err != nil && !strings.HasPrefix(err.Error(), "NoSuchLifecycleConfiguration") {
+	if err != nil && !lib.HasErrorCode(err, lib.ErrCodeNoSuchLifecycleConfiguration) {
 		resp.Diagnostics.AddError("Failed to get bucket lifecycle policy", err.Error())
 		return
 	}
@@ -186,7 +215,7 @@ func (r *RgwBucketResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &data)
+	diags = resp.State.Set(ctx, &data)
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -205,13 +234,22 @@ func (r *RgwBucketResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwBucketReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	var name = data.Name.ValueString()
 
 	bucket, err := r.clientLibs.Rgw.GetBucketInfo(ctx, admin.Bucket{Bucket: name})
 
 	if err != nil {
-		// Check if the error is a "NoSuchBucket" error
-		if strings.HasPrefix(err.Error(), "NoSuchBucket") {
+		// Check if the bucket is gone
+		if lib.IsNotFound(err) {
 			tflog.Debug(ctx, "Bucket "+name+" not found, removing from state")
 			resp.State.RemoveResource(ctx)
 			return
@@ -221,9 +259,16 @@ func (r *RgwBucketResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	var priorPlacementRule = data.PlacementRule
+	var priorTimeouts = data.Timeouts
+
 	data = model.ToRgwBucket(bucket)
+	data.Timeouts = priorTimeouts
+	model.NormalizeRgwBucketPlacement(&data, priorPlacementRule, func() string {
+		return r.clientLibs.GetDefaultPlacement(ctx)
+	})
 
-	versioning, err := r.clientLibs.S3.GetBucketVersioning(&s3.GetBucketVersioningInput{
+	versioning, err := r.clientLibs.S3.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 		Bucket: data.Name.ValueStringPointer(),
 	})
 	if err != nil {
@@ -231,17 +276,17 @@ func (r *RgwBucketResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	if versioning.Status != nil && *versioning.Status == s3.BucketVersioningStatusEnabled {
+	if versioning.Status == s3types.BucketVersioningStatusEnabled {
 		data.VersioningEnabled = types.BoolValue(true)
 	} else {
 		data.VersioningEnabled = types.BoolValue(false)
 	}
 
 	// Now get bucket policy and set it in the state
-	policyJson, err := r.clientLibs.S3.GetBucketPolicy(&s3.GetBucketPolicyInput{
+	policyJson, err := r.clientLibs.S3.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
 		Bucket: &name,
 	})
-	if err != nil && !strings.HasPrefix(err.Error(), "NoSuchBucketPolicy") {
+	if err != nil && !lib.HasErrorCode(err, lib.ErrCodeNoSuchBucketPolicy) {
 		resp.Diagnostics.AddError("Failed to get bucket policy", err.Error())
 		return
 	}
@@ -257,10 +302,10 @@ func (r *RgwBucketResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Now get bucket lifecycle policy and set it in the state
-	lifecyclePolicy, err := r.clientLibs.S3.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+	lifecyclePolicy, err := r.clientLibs.S3.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
 		Bucket: &name,
 	})
-	if err != nil && !strings.HasPrefix(err.Error(), "NoSuchLifecycleConfiguration") {
+	if err != nil && !lib.HasErrorCode(err, lib.ErrCodeNoSuchLifecycleConfiguration) {
 		resp.Diagnostics.AddError("Failed to get bucket lifecycle policy", err.Error())
 		return
 	}
@@ -269,7 +314,7 @@ func (r *RgwBucketResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Set state
-	diags := resp.State.Set(ctx, &data)
+	diags = resp.State.Set(ctx, &data)
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -292,26 +337,30 @@ func (r *RgwBucketResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	if desired.Name != state.Name {
-		resp.Diagnostics.AddError("Update not supported", "Bucket name cannot be changed")
+	updateTimeout, diags := desired.Timeouts.Update(ctx, defaultRgwBucketUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if !desired.PlacementRule.IsNull() && !desired.PlacementRule.IsUnknown() && desired.PlacementRule != state.PlacementRule {
-		resp.Diagnostics.AddError("Update not supported", "Bucket placement rule cannot be changed from "+state.PlacementRule.String()+" to "+desired.PlacementRule.String())
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if desired.Name != state.Name {
+		resp.Diagnostics.AddError("Update not supported", "Bucket name cannot be changed")
 		return
 	}
 
-	var status string
+	var status s3types.BucketVersioningStatus
 	if desired.VersioningEnabled.ValueBool() {
-		status = s3.BucketVersioningStatusEnabled
+		status = s3types.BucketVersioningStatusEnabled
 	} else {
-		status = s3.BucketVersioningStatusSuspended
+		status = s3types.BucketVersioningStatusSuspended
 	}
-	_, err := r.clientLibs.S3.PutBucketVersioning(&s3.PutBucketVersioningInput{
+	_, err := r.clientLibs.S3.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
 		Bucket: state.Name.ValueStringPointer(),
-		VersioningConfiguration: &s3.VersioningConfiguration{
-			Status: &status,
+		VersioningConfiguration: &s3types.VersioningConfiguration{
+			Status: status,
 		},
 	})
 
@@ -328,7 +377,7 @@ func (r *RgwBucketResource) Update(ctx context.Context, req resource.UpdateReque
 			resp.Diagnostics.AddError("Failed to generate bucket policy", err.Error())
 			return
 		}
-		_, err = r.clientLibs.S3.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		_, err = r.clientLibs.S3.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
 			Bucket: state.Name.ValueStringPointer(),
 			Policy: &s3BucketPolicyJson,
 		})
@@ -337,7 +386,7 @@ func (r *RgwBucketResource) Update(ctx context.Context, req resource.UpdateReque
 			return
 		}
 	} else {
-		_, err := r.clientLibs.S3.DeleteBucketPolicy(&s3.DeleteBucketPolicyInput{
+		_, err := r.clientLibs.S3.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
 			Bucket: state.Name.ValueStringPointer(),
 		})
 		if err != nil {
@@ -348,7 +397,7 @@ func (r *RgwBucketResource) Update(ctx context.Context, req resource.UpdateReque
 
 	if len(desired.LifecycleDelete) > 0 {
 		s3LifecyclePolicy := model.GenerateS3LifecyclePolicyFromBucket(&desired)
-		_, err := r.clientLibs.S3.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+		_, err := r.clientLibs.S3.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
 			Bucket:                 state.Name.ValueStringPointer(),
 			LifecycleConfiguration: &s3LifecyclePolicy,
 		})
@@ -357,7 +406,7 @@ func (r *RgwBucketResource) Update(ctx context.Context, req resource.UpdateReque
 			return
 		}
 	} else {
-		_, err := r.clientLibs.S3.DeleteBucketLifecycle(&s3.DeleteBucketLifecycleInput{
+		_, err := r.clientLibs.S3.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{
 			Bucket: state.Name.ValueStringPointer(),
 		})
 		if err != nil {
@@ -369,9 +418,10 @@ func (r *RgwBucketResource) Update(ctx context.Context, req resource.UpdateReque
 	// Update the state
 	state.Permissions = desired.Permissions
 	state.LifecycleDelete = desired.LifecycleDelete
+	state.Timeouts = desired.Timeouts
 
 	// Set state (for now, set it to the state)
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -390,6 +440,15 @@ func (r *RgwBucketResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwBucketDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.clientLibs.Rgw.RemoveBucket(ctx, admin.Bucket{Bucket: data.Name.ValueString()})
 	if err != nil {
 		resp.Diagnostics.AddError("DeleteBucket failed", err.Error())
@@ -397,6 +456,46 @@ func (r *RgwBucketResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 }
 
+// resolvePlacement validates the requested placement target and storage
+// class against the zonegroup placement targets and returns the placement
+// target to create the bucket in. Validation is skipped when the zonegroup
+// cannot be read, leaving RGW to reject invalid placements.
+func (r *RgwBucketResource) resolvePlacement(ctx context.Context, placement string, storageClass string) (string, error) {
+	if placement == "" && storageClass == "" {
+		return "", nil
+	}
+
+	zoneGroup, found, err := r.clientLibs.GetZoneGroup(ctx)
+	if err != nil || !found {
+		if err != nil {
+			tflog.Warn(ctx, "Unable to read zonegroup, skipping placement validation: "+err.Error())
+		}
+		if placement == "" {
+			placement = lib.RgwDefaultPlacement
+		}
+		return placement, nil
+	}
+
+	if placement == "" {
+		placement = zoneGroup.GetDefaultPlacement()
+	}
+
+	target, found := zoneGroup.FindPlacementTarget(placement)
+	if !found {
+		var available []string
+		for _, candidate := range zoneGroup.PlacementTargets {
+			available = append(available, candidate.Name)
+		}
+		return "", fmt.Errorf("placement target %q does not exist in zonegroup %q, available placement targets: %s", placement, zoneGroup.Name, strings.Join(available, ", "))
+	}
+
+	if storageClass != "" && !target.HasStorageClass(storageClass) {
+		return "", fmt.Errorf("storage class %q does not exist in placement target %q of zonegroup %q, available storage classes: %s", storageClass, placement, zoneGroup.Name, strings.Join(target.GetStorageClasses(), ", "))
+	}
+
+	return placement, nil
+}
+
 func (r *RgwBucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
@@ -0,0 +1,168 @@
+package resources_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccRgwBucketResource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwBucketConfig("photos", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "name", "photos"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "placement_rule", "default-placement"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "versioning_enabled", "false"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "num_shards", "11"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "permission.#", "1"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "permission.0.user_id", "reader"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "permission.0.permissions.#", "2"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "lifecycle_delete.#", "1"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "lifecycle_delete.0.object_prefix", "tmp/"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "lifecycle_delete.0.after_days", "7"),
+				),
+			},
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwBucketConfig("photos", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "versioning_enabled", "true"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "num_shards", "11"),
+				),
+			},
+			{
+				ResourceName:                         "ceph_rgw_bucket.test",
+				ImportState:                          true,
+				ImportStateId:                        "photos",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccRgwBucketResource_defaults(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_bucket" "test" {
+  name = "plain"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "placement_rule", "default-placement"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "versioning_enabled", "false"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "num_shards", "11"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "permission.#", "0"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "lifecycle_delete.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwBucketResource_unknownPlacement(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_bucket" "test" {
+  name           = "misplaced"
+  placement_rule = "fast-placement"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid bucket placement`),
+			},
+		},
+	})
+}
+
+func TestAccRgwBucketResource_replacePlacement(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwBucketPlacementConfig("default-placement", "STANDARD"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "placement_rule", "default-placement"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket.test", "storage_class", "STANDARD"),
+				),
+			},
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwBucketPlacementConfig("default-placement", "COLD"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionReplace),
+					},
+				},
+				ExpectError: regexp.MustCompile(`Invalid bucket placement`),
+			},
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwBucketPlacementConfig("default-placement", "STANDARD"),
+			},
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwBucketPlacementConfig("fast-placement", "STANDARD"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ceph_rgw_bucket.test", plancheck.ResourceActionReplace),
+					},
+				},
+				ExpectError: regexp.MustCompile(`Invalid bucket placement`),
+			},
+		},
+	})
+}
+
+func testAccRgwBucketPlacementConfig(placement string, storageClass string) string {
+	return fmt.Sprintf(`
+resource "ceph_rgw_bucket" "test" {
+  name           = "placed"
+  placement_rule = %q
+  storage_class  = %q
+}
+`, placement, storageClass)
+}
+
+func testAccRgwBucketConfig(name string, versioning bool) string {
+	return fmt.Sprintf(`
+resource "ceph_rgw_user" "reader" {
+  id   = "reader"
+  name = "Reader"
+}
+
+resource "ceph_rgw_bucket" "test" {
+  name               = %q
+  versioning_enabled = %t
+
+  permission {
+    user_id     = ceph_rgw_user.reader.id
+    permissions = ["s3:GetObject", "s3:ListBucket"]
+  }
+
+  lifecycle_delete {
+    id            = "expire-tmp"
+    object_prefix = "tmp/"
+    after_days    = 7
+  }
+}
+`, name, versioning)
+}
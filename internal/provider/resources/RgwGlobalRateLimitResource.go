@@ -0,0 +1,220 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwGlobalRateLimitResource{}
+	_ resource.ResourceWithConfigure   = &RgwGlobalRateLimitResource{}
+	_ resource.ResourceWithImportState = &RgwGlobalRateLimitResource{}
+)
+
+const (
+	defaultRgwGlobalRateLimitCreateTimeout = 5 * time.Minute
+	defaultRgwGlobalRateLimitReadTimeout   = 5 * time.Minute
+	defaultRgwGlobalRateLimitUpdateTimeout = 5 * time.Minute
+	defaultRgwGlobalRateLimitDeleteTimeout = 5 * time.Minute
+)
+
+type RgwGlobalRateLimitResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwGlobalRateLimitResource() resource.Resource {
+	return &RgwGlobalRateLimitResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwGlobalRateLimitResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_global_ratelimit"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwGlobalRateLimitResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwGlobalRateLimitResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwGlobalRateLimitResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwGlobalRateLimitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwGlobalRateLimit
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwGlobalRateLimitCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.clientLibs.Admin.SetGlobalRateLimit(ctx, data.Scope.ValueString(), data.RateLimit())
+	if err != nil {
+		resp.Diagnostics.AddError("SetGlobalRateLimit failed", err.Error())
+		return
+	}
+
+	limit, err := r.clientLibs.Admin.GetGlobalRateLimit(ctx, data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("GetGlobalRateLimit failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwGlobalRateLimit(data.Scope.ValueString(), limit)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwGlobalRateLimitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwGlobalRateLimit
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwGlobalRateLimitReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	limit, err := r.clientLibs.Admin.GetGlobalRateLimit(ctx, data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("GetGlobalRateLimit failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwGlobalRateLimit(data.Scope.ValueString(), limit)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwGlobalRateLimitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data model.RgwGlobalRateLimit
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultRgwGlobalRateLimitUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.clientLibs.Admin.SetGlobalRateLimit(ctx, data.Scope.ValueString(), data.RateLimit())
+	if err != nil {
+		resp.Diagnostics.AddError("SetGlobalRateLimit failed", err.Error())
+		return
+	}
+
+	limit, err := r.clientLibs.Admin.GetGlobalRateLimit(ctx, data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("GetGlobalRateLimit failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwGlobalRateLimit(data.Scope.ValueString(), limit)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete resets the rate limit, which cannot be removed, to unlimited and
+// disabled.
+func (r *RgwGlobalRateLimitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwGlobalRateLimit
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwGlobalRateLimitDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.clientLibs.Admin.SetGlobalRateLimit(ctx, data.Scope.ValueString(), lib.RgwRateLimit{})
+	if err != nil {
+		resp.Diagnostics.AddError("SetGlobalRateLimit failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwGlobalRateLimitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("scope"), req, resp)
+}
@@ -0,0 +1,276 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwIamAccessKeyResource{}
+	_ resource.ResourceWithConfigure   = &RgwIamAccessKeyResource{}
+	_ resource.ResourceWithImportState = &RgwIamAccessKeyResource{}
+)
+
+const (
+	defaultRgwIamAccessKeyCreateTimeout = 5 * time.Minute
+	defaultRgwIamAccessKeyReadTimeout   = 5 * time.Minute
+	defaultRgwIamAccessKeyUpdateTimeout = 5 * time.Minute
+	defaultRgwIamAccessKeyDeleteTimeout = 5 * time.Minute
+)
+
+type RgwIamAccessKeyResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwIamAccessKeyResource() resource.Resource {
+	return &RgwIamAccessKeyResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwIamAccessKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_iam_access_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwIamAccessKeyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwIamAccessKeyResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwIamAccessKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwIamAccessKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwIamAccessKey
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwIamAccessKeyCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.CreateAccessKeyWithContext(ctx, &iam.CreateAccessKeyInput{
+		UserName: data.User.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("CreateAccessKey failed", err.Error())
+		return
+	}
+
+	key := output.AccessKey
+	status := data.Status
+
+	data = model.ToRgwIamAccessKey(&iam.AccessKeyMetadata{
+		UserName:    key.UserName,
+		AccessKeyId: key.AccessKeyId,
+		Status:      key.Status,
+		CreateDate:  key.CreateDate,
+	}, data)
+	data.Secret = types.StringValue(aws.StringValue(key.SecretAccessKey))
+
+	// Keys are created active
+	if !status.Equal(data.Status) {
+		err = r.updateStatus(ctx, data.User.ValueString(), data.Id.ValueString(), status.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("UpdateAccessKey failed", err.Error())
+
+			// Remove the key rather than leave an active one behind that is
+			// not in the state
+			_, err = r.clientLibs.IAM.DeleteAccessKeyWithContext(ctx, &iam.DeleteAccessKeyInput{
+				UserName:    data.User.ValueStringPointer(),
+				AccessKeyId: data.Id.ValueStringPointer(),
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("DeleteAccessKey failed", "Access key "+data.Id.ValueString()+" was created but could not be removed after UpdateAccessKey failed: "+err.Error())
+			}
+			return
+		}
+		data.Status = status
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwIamAccessKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwIamAccessKey
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwIamAccessKeyReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var found *iam.AccessKeyMetadata
+	err := r.clientLibs.IAM.ListAccessKeysPagesWithContext(ctx, &iam.ListAccessKeysInput{
+		UserName: data.User.ValueStringPointer(),
+	}, func(page *iam.ListAccessKeysOutput, _ bool) bool {
+		for _, metadata := range page.AccessKeyMetadata {
+			if aws.StringValue(metadata.AccessKeyId) == data.Id.ValueString() {
+				found = metadata
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("ListAccessKeys failed", err.Error())
+		return
+	}
+
+	if found == nil {
+		tflog.Debug(ctx, "Access key "+data.Id.ValueString()+" of IAM user "+data.User.ValueString()+" not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if found.UserName == nil {
+		found.UserName = data.User.ValueStringPointer()
+	}
+
+	data = model.ToRgwIamAccessKey(found, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwIamAccessKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data model.RgwIamAccessKey
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultRgwIamAccessKeyUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.updateStatus(ctx, data.User.ValueString(), data.Id.ValueString(), data.Status.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("UpdateAccessKey failed", err.Error())
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwIamAccessKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwIamAccessKey
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwIamAccessKeyDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.DeleteAccessKeyWithContext(ctx, &iam.DeleteAccessKeyInput{
+		UserName:    data.User.ValueStringPointer(),
+		AccessKeyId: data.Id.ValueStringPointer(),
+	})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("DeleteAccessKey failed", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing access key. Its secret cannot be read back.
+func (r *RgwIamAccessKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	user, id, found := strings.Cut(req.ID, "/")
+	if !found || user == "" || id == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected an import ID of the form <user>/<access_key_id>, got: "+req.ID)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), user)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (r *RgwIamAccessKeyResource) updateStatus(ctx context.Context, user, id, status string) error {
+	_, err := r.clientLibs.IAM.UpdateAccessKeyWithContext(ctx, &iam.UpdateAccessKeyInput{
+		UserName:    aws.String(user),
+		AccessKeyId: aws.String(id),
+		Status:      aws.String(status),
+	})
+	return err
+}
@@ -0,0 +1,271 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwIamGroupMembershipResource{}
+	_ resource.ResourceWithConfigure   = &RgwIamGroupMembershipResource{}
+	_ resource.ResourceWithImportState = &RgwIamGroupMembershipResource{}
+)
+
+const (
+	defaultRgwIamGroupMembershipCreateTimeout = 5 * time.Minute
+	defaultRgwIamGroupMembershipReadTimeout   = 5 * time.Minute
+	defaultRgwIamGroupMembershipUpdateTimeout = 5 * time.Minute
+	defaultRgwIamGroupMembershipDeleteTimeout = 5 * time.Minute
+)
+
+type RgwIamGroupMembershipResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwIamGroupMembershipResource() resource.Resource {
+	return &RgwIamGroupMembershipResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwIamGroupMembershipResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_iam_group_membership"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwIamGroupMembershipResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwIamGroupMembershipResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwIamGroupMembershipResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwIamGroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwIamGroupMembership
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwIamGroupMembershipCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var users []string
+	resp.Diagnostics.Append(data.Users.ElementsAs(ctx, &users, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.addUsers(ctx, data.Group.ValueString(), users)
+	if err != nil {
+		resp.Diagnostics.AddError("AddUserToGroup failed", err.Error())
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwIamGroupMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwIamGroupMembership
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwIamGroupMembershipReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	users := []string{}
+	err := r.clientLibs.IAM.GetGroupPagesWithContext(ctx, &iam.GetGroupInput{
+		GroupName: data.Group.ValueStringPointer(),
+	}, func(page *iam.GetGroupOutput, _ bool) bool {
+		for _, user := range page.Users {
+			users = append(users, aws.StringValue(user.UserName))
+		}
+		return true
+	})
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "IAM group "+data.Group.ValueString()+" not found, removing membership from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetGroup failed", err.Error())
+		return
+	}
+
+	data.Users, _ = types.SetValueFrom(ctx, types.StringType, users)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwIamGroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state model.RgwIamGroupMembership
+	var desired model.RgwIamGroupMembership
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &desired)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := desired.Timeouts.Update(ctx, defaultRgwIamGroupMembershipUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var current []string
+	var users []string
+	resp.Diagnostics.Append(state.Users.ElementsAs(ctx, &current, false)...)
+	resp.Diagnostics.Append(desired.Users.ElementsAs(ctx, &users, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.addUsers(ctx, desired.Group.ValueString(), lib.Difference(users, current))
+	if err != nil {
+		resp.Diagnostics.AddError("AddUserToGroup failed", err.Error())
+		return
+	}
+
+	err = r.removeUsers(ctx, desired.Group.ValueString(), lib.Difference(current, users))
+	if err != nil {
+		resp.Diagnostics.AddError("RemoveUserFromGroup failed", err.Error())
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &desired)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwIamGroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwIamGroupMembership
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwIamGroupMembershipDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var users []string
+	resp.Diagnostics.Append(data.Users.ElementsAs(ctx, &users, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.removeUsers(ctx, data.Group.ValueString(), users)
+	if err != nil {
+		resp.Diagnostics.AddError("RemoveUserFromGroup failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwIamGroupMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("group"), req, resp)
+}
+
+func (r *RgwIamGroupMembershipResource) addUsers(ctx context.Context, group string, users []string) error {
+	for _, user := range users {
+		_, err := r.clientLibs.IAM.AddUserToGroupWithContext(ctx, &iam.AddUserToGroupInput{
+			GroupName: aws.String(group),
+			UserName:  aws.String(user),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RgwIamGroupMembershipResource) removeUsers(ctx context.Context, group string, users []string) error {
+	for _, user := range users {
+		_, err := r.clientLibs.IAM.RemoveUserFromGroupWithContext(ctx, &iam.RemoveUserFromGroupInput{
+			GroupName: aws.String(group),
+			UserName:  aws.String(user),
+		})
+		if err != nil && !lib.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
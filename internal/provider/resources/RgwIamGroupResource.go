@@ -0,0 +1,232 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwIamGroupResource{}
+	_ resource.ResourceWithConfigure   = &RgwIamGroupResource{}
+	_ resource.ResourceWithImportState = &RgwIamGroupResource{}
+)
+
+const (
+	defaultRgwIamGroupCreateTimeout = 5 * time.Minute
+	defaultRgwIamGroupReadTimeout   = 5 * time.Minute
+	defaultRgwIamGroupUpdateTimeout = 5 * time.Minute
+	defaultRgwIamGroupDeleteTimeout = 5 * time.Minute
+)
+
+type RgwIamGroupResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwIamGroupResource() resource.Resource {
+	return &RgwIamGroupResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwIamGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_iam_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwIamGroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwIamGroupResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwIamGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwIamGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwIamGroup
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwIamGroupCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.CreateGroupWithContext(ctx, &iam.CreateGroupInput{
+		GroupName: data.Name.ValueStringPointer(),
+		Path:      data.Path.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("CreateGroup failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwIamGroup(output.Group)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwIamGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwIamGroup
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwIamGroupReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.GetGroupWithContext(ctx, &iam.GetGroupInput{
+		GroupName: data.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "IAM group "+data.Name.ValueString()+" not found, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetGroup failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwIamGroup(output.Group)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwIamGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data model.RgwIamGroup
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultRgwIamGroupUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.UpdateGroupWithContext(ctx, &iam.UpdateGroupInput{
+		GroupName: data.Name.ValueStringPointer(),
+		NewPath:   data.Path.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("UpdateGroup failed", err.Error())
+		return
+	}
+
+	output, err := r.clientLibs.IAM.GetGroupWithContext(ctx, &iam.GetGroupInput{
+		GroupName: data.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("GetGroup failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwIamGroup(output.Group)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwIamGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwIamGroup
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwIamGroupDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.DeleteGroupWithContext(ctx, &iam.DeleteGroupInput{
+		GroupName: data.Name.ValueStringPointer(),
+	})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("DeleteGroup failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwIamGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
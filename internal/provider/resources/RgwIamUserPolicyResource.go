@@ -0,0 +1,227 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwIamUserPolicyResource{}
+	_ resource.ResourceWithConfigure   = &RgwIamUserPolicyResource{}
+	_ resource.ResourceWithImportState = &RgwIamUserPolicyResource{}
+)
+
+const (
+	defaultRgwIamUserPolicyCreateTimeout = 5 * time.Minute
+	defaultRgwIamUserPolicyReadTimeout   = 5 * time.Minute
+	defaultRgwIamUserPolicyUpdateTimeout = 5 * time.Minute
+	defaultRgwIamUserPolicyDeleteTimeout = 5 * time.Minute
+)
+
+type RgwIamUserPolicyResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwIamUserPolicyResource() resource.Resource {
+	return &RgwIamUserPolicyResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwIamUserPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_iam_user_policy"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwIamUserPolicyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwIamUserPolicyResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwIamUserPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwIamUserPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwIamUserPolicy
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwIamUserPolicyCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.put(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("PutUserPolicy failed", err.Error())
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwIamUserPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwIamUserPolicy
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwIamUserPolicyReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.GetUserPolicyWithContext(ctx, &iam.GetUserPolicyInput{
+		UserName:   data.User.ValueStringPointer(),
+		PolicyName: data.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "User policy "+data.User.ValueString()+"/"+data.Name.ValueString()+" not found, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetUserPolicy failed", err.Error())
+		return
+	}
+
+	data = model.ToRgwIamUserPolicy(output, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwIamUserPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data model.RgwIamUserPolicy
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultRgwIamUserPolicyUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.put(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("PutUserPolicy failed", err.Error())
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwIamUserPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwIamUserPolicy
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwIamUserPolicyDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.DeleteUserPolicyWithContext(ctx, &iam.DeleteUserPolicyInput{
+		UserName:   data.User.ValueStringPointer(),
+		PolicyName: data.Name.ValueStringPointer(),
+	})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("DeleteUserPolicy failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwIamUserPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	user, name, found := strings.Cut(req.ID, "/")
+	if !found || user == "" || name == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected an import ID of the form <user>/<name>, got: "+req.ID)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), user)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func (r *RgwIamUserPolicyResource) put(ctx context.Context, data model.RgwIamUserPolicy) error {
+	_, err := r.clientLibs.IAM.PutUserPolicyWithContext(ctx, &iam.PutUserPolicyInput{
+		UserName:       data.User.ValueStringPointer(),
+		PolicyName:     data.Name.ValueStringPointer(),
+		PolicyDocument: data.Policy.ValueStringPointer(),
+	})
+	return err
+}
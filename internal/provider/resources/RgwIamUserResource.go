@@ -0,0 +1,232 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwIamUserResource{}
+	_ resource.ResourceWithConfigure   = &RgwIamUserResource{}
+	_ resource.ResourceWithImportState = &RgwIamUserResource{}
+)
+
+const (
+	defaultRgwIamUserCreateTimeout = 5 * time.Minute
+	defaultRgwIamUserReadTimeout   = 5 * time.Minute
+	defaultRgwIamUserUpdateTimeout = 5 * time.Minute
+	defaultRgwIamUserDeleteTimeout = 5 * time.Minute
+)
+
+type RgwIamUserResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwIamUserResource() resource.Resource {
+	return &RgwIamUserResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwIamUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_iam_user"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwIamUserResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwIamUserResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwIamUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwIamUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwIamUser
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwIamUserCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.CreateUserWithContext(ctx, &iam.CreateUserInput{
+		UserName: data.Name.ValueStringPointer(),
+		Path:     data.Path.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("CreateUser failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwIamUser(output.User)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwIamUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwIamUser
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwIamUserReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.GetUserWithContext(ctx, &iam.GetUserInput{
+		UserName: data.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "IAM user "+data.Name.ValueString()+" not found, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetUser failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwIamUser(output.User)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwIamUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data model.RgwIamUser
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultRgwIamUserUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.UpdateUserWithContext(ctx, &iam.UpdateUserInput{
+		UserName: data.Name.ValueStringPointer(),
+		NewPath:  data.Path.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("UpdateUser failed", err.Error())
+		return
+	}
+
+	output, err := r.clientLibs.IAM.GetUserWithContext(ctx, &iam.GetUserInput{
+		UserName: data.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("GetUser failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwIamUser(output.User)
+	data.Timeouts = timeouts
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwIamUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwIamUser
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwIamUserDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.DeleteUserWithContext(ctx, &iam.DeleteUserInput{
+		UserName: data.Name.ValueStringPointer(),
+	})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("DeleteUser failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwIamUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
@@ -0,0 +1,383 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwObjectResource{}
+	_ resource.ResourceWithConfigure   = &RgwObjectResource{}
+	_ resource.ResourceWithImportState = &RgwObjectResource{}
+	_ resource.ResourceWithModifyPlan  = &RgwObjectResource{}
+)
+
+// Multipart uploads of large objects can take a while, hence the longer
+// create and update timeouts.
+const (
+	defaultRgwObjectCreateTimeout = 30 * time.Minute
+	defaultRgwObjectReadTimeout   = 5 * time.Minute
+	defaultRgwObjectUpdateTimeout = 30 * time.Minute
+	defaultRgwObjectDeleteTimeout = 5 * time.Minute
+)
+
+type RgwObjectResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwObjectResource() resource.Resource {
+	return &RgwObjectResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwObjectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_object"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwObjectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwObjectResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwObjectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// ModifyPlan predicts the ETag of the configured content so that changes made
+// to the object outside of Terraform show up as a diff.
+func (r *RgwObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan model.RgwObject
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	etag, ok, err := model.ExpectedRgwObjectEtag(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read object content", err.Error())
+		return
+	}
+	if !ok {
+		return
+	}
+
+	plan.Etag = types.StringValue(etag)
+
+	if !req.State.Raw.IsNull() {
+		var state model.RgwObject
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !state.Etag.Equal(plan.Etag) {
+			plan.VersionId = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwObject
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwObjectCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.upload(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := r.read(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read object "+data.Key.ValueString(), err.Error())
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("Failed to read object "+data.Key.ValueString(), "Object not found after upload")
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwObject
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwObjectReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	found, err := r.read(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read object "+data.Key.ValueString(), err.Error())
+		return
+	}
+	if !found {
+		tflog.Debug(ctx, "Object "+data.Key.ValueString()+" not found, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var desired model.RgwObject
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &desired)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := desired.Timeouts.Update(ctx, defaultRgwObjectUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Every attribute of an object is set at upload time, so any change
+	// re-uploads it.
+	resp.Diagnostics.Append(r.upload(ctx, &desired)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := r.read(ctx, &desired)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read object "+desired.Key.ValueString(), err.Error())
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError("Failed to read object "+desired.Key.ValueString(), "Object not found after upload")
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &desired)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwObject
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwObjectDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.S3.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: data.Bucket.ValueStringPointer(),
+		Key:    data.Key.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("DeleteObject failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwObjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	bucket, key, found := strings.Cut(req.ID, "/")
+	if !found || bucket == "" || key == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected an import ID of the form <bucket>/<key>, got: "+req.ID)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
+
+// upload puts the configured content into the bucket, switching to a
+// multipart upload for content larger than a single part.
+func (r *RgwObjectResource) upload(ctx context.Context, data *model.RgwObject) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	body, size, err := model.OpenRgwObjectBody(data)
+	if err != nil {
+		diags.AddError("Failed to upload object "+data.Key.ValueString(), err.Error())
+		return diags
+	}
+	defer body.Close()
+
+	input := s3.PutObjectInput{
+		Bucket: data.Bucket.ValueStringPointer(),
+		Key:    data.Key.ValueStringPointer(),
+		Body:   body,
+	}
+
+	if !data.ContentType.IsNull() && !data.ContentType.IsUnknown() {
+		input.ContentType = data.ContentType.ValueStringPointer()
+	}
+	if !data.StorageClass.IsNull() && !data.StorageClass.IsUnknown() {
+		input.StorageClass = s3types.StorageClass(data.StorageClass.ValueString())
+	}
+	if !data.ServerSideEncryption.IsNull() && !data.ServerSideEncryption.IsUnknown() {
+		input.ServerSideEncryption = s3types.ServerSideEncryption(data.ServerSideEncryption.ValueString())
+	}
+	if !data.KmsKeyId.IsNull() && !data.KmsKeyId.IsUnknown() {
+		input.SSEKMSKeyId = data.KmsKeyId.ValueStringPointer()
+	}
+
+	if !data.Metadata.IsNull() {
+		var metadata map[string]string
+		diags.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
+		input.Metadata = metadata
+	}
+
+	if !data.Tags.IsNull() {
+		var tags map[string]string
+		diags.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+		input.Tagging = aws.String(model.EncodeS3Tagging(tags))
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	uploader := manager.NewUploader(r.clientLibs.S3, func(u *manager.Uploader) {
+		u.PartSize = lib.S3PartSizeFor(size)
+	})
+
+	_, err = uploader.Upload(ctx, &input)
+	if err != nil {
+		diags.AddError("Failed to upload object "+data.Key.ValueString(), err.Error())
+	}
+	return diags
+}
+
+// read refreshes the attributes RGW reports for the object. It returns false
+// when the object does not exist.
+func (r *RgwObjectResource) read(ctx context.Context, data *model.RgwObject) (bool, error) {
+	head, err := r.clientLibs.S3.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: data.Bucket.ValueStringPointer(),
+		Key:    data.Key.ValueStringPointer(),
+	})
+	if err != nil {
+		if lib.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	data.Etag = types.StringValue(lib.NormalizeS3Etag(head.ETag))
+	data.VersionId = types.StringPointerValue(head.VersionId)
+	data.ContentType = types.StringPointerValue(head.ContentType)
+	data.KmsKeyId = types.StringPointerValue(head.SSEKMSKeyId)
+
+	if head.ServerSideEncryption != "" {
+		data.ServerSideEncryption = types.StringValue(string(head.ServerSideEncryption))
+	} else {
+		data.ServerSideEncryption = types.StringNull()
+	}
+
+	if head.StorageClass != "" {
+		data.StorageClass = types.StringValue(string(head.StorageClass))
+	} else {
+		data.StorageClass = types.StringValue(string(s3types.StorageClassStandard))
+	}
+
+	model.ReadS3MetadataIntoObject(data, head.Metadata)
+
+	tagging, err := r.clientLibs.S3.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket: data.Bucket.ValueStringPointer(),
+		Key:    data.Key.ValueStringPointer(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	model.ReadS3TagsIntoObject(data, tagging.TagSet)
+
+	return true, nil
+}
@@ -0,0 +1,50 @@
+package resources_test
+
+import (
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwObjectResource_timeouts(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_bucket" "test" {
+  name = "backups"
+}
+
+resource "ceph_rgw_object" "test" {
+  bucket  = ceph_rgw_bucket.test.name
+  key     = "db.dump"
+  content = "dump"
+
+  timeouts {
+    create = "2h"
+    update = "2h"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_object.test", "etag", "b9ef165b255673dde47bff07f4390fb1"),
+					resource.TestCheckResourceAttr("ceph_rgw_object.test", "timeouts.create", "2h"),
+					resource.TestCheckResourceAttr("ceph_rgw_object.test", "timeouts.update", "2h"),
+				),
+			},
+			{
+				ResourceName:                         "ceph_rgw_object.test",
+				ImportState:                          true,
+				ImportStateId:                        "backups/db.dump",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "key",
+				ImportStateVerifyIgnore:              []string{"content", "timeouts"},
+			},
+		},
+	})
+}
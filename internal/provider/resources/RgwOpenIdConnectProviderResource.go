@@ -0,0 +1,213 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwOpenIdConnectProviderResource{}
+	_ resource.ResourceWithConfigure   = &RgwOpenIdConnectProviderResource{}
+	_ resource.ResourceWithImportState = &RgwOpenIdConnectProviderResource{}
+)
+
+const (
+	defaultRgwOpenIdConnectProviderCreateTimeout = 5 * time.Minute
+	defaultRgwOpenIdConnectProviderReadTimeout   = 5 * time.Minute
+	defaultRgwOpenIdConnectProviderDeleteTimeout = 5 * time.Minute
+)
+
+type RgwOpenIdConnectProviderResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwOpenIdConnectProviderResource() resource.Resource {
+	return &RgwOpenIdConnectProviderResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwOpenIdConnectProviderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_openid_connect_provider"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwOpenIdConnectProviderResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwOpenIdConnectProviderResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwOpenIdConnectProviderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwOpenIdConnectProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwOpenIdConnectProvider
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwOpenIdConnectProviderCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var clientIds []string
+	var thumbprints []string
+	resp.Diagnostics.Append(data.ClientIdList.ElementsAs(ctx, &clientIds, false)...)
+	resp.Diagnostics.Append(data.ThumbprintList.ElementsAs(ctx, &thumbprints, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.clientLibs.IAM.CreateOpenIDConnectProviderWithContext(ctx, &iam.CreateOpenIDConnectProviderInput{
+		Url:            data.Url.ValueStringPointer(),
+		ClientIDList:   aws.StringSlice(clientIds),
+		ThumbprintList: aws.StringSlice(thumbprints),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("CreateOpenIDConnectProvider failed", err.Error())
+		return
+	}
+
+	arn := aws.StringValue(created.OpenIDConnectProviderArn)
+
+	output, err := r.clientLibs.IAM.GetOpenIDConnectProviderWithContext(ctx, &iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(arn),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("GetOpenIDConnectProvider failed", err.Error())
+		return
+	}
+
+	data = model.ToRgwOpenIdConnectProvider(arn, output, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwOpenIdConnectProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwOpenIdConnectProvider
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwOpenIdConnectProviderReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.GetOpenIDConnectProviderWithContext(ctx, &iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: data.Arn.ValueStringPointer(),
+	})
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "OpenID Connect provider "+data.Arn.ValueString()+" not found, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetOpenIDConnectProvider failed", err.Error())
+		return
+	}
+
+	data = model.ToRgwOpenIdConnectProvider(data.Arn.ValueString(), output, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the plan, as every attribute but the timeouts requires a
+// replacement.
+func (r *RgwOpenIdConnectProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data model.RgwOpenIdConnectProvider
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwOpenIdConnectProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwOpenIdConnectProvider
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwOpenIdConnectProviderDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.DeleteOpenIDConnectProviderWithContext(ctx, &iam.DeleteOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: data.Arn.ValueStringPointer(),
+	})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("DeleteOpenIDConnectProvider failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwOpenIdConnectProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("arn"), req, resp)
+}
@@ -0,0 +1,182 @@
+package resources_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRgwUserRateLimitResource(t *testing.T) {
+	server := acctest.NewServer(t)
+	server.AddUser("reporter", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwRateLimitConfig("ceph_rgw_user_ratelimit", "user_id", "reporter", 100, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "user_id", "reporter"),
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "max_read_ops", "100"),
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "max_write_ops", "10"),
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "max_read_bytes", "0"),
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "max_write_bytes", "0"),
+				),
+			},
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwRateLimitConfig("ceph_rgw_user_ratelimit", "user_id", "reporter", 200, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "max_read_ops", "200"),
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "max_write_ops", "0"),
+				),
+			},
+			{
+				ResourceName:                         "ceph_rgw_user_ratelimit.test",
+				ImportState:                          true,
+				ImportStateId:                        "reporter",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "user_id",
+			},
+		},
+	})
+}
+
+func TestAccRgwUserRateLimitResource_unknownUser(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.ProviderConfig(server) + testAccRgwRateLimitConfig("ceph_rgw_user_ratelimit", "user_id", "nobody", 100, 10),
+				ExpectError: regexp.MustCompile(`NoSuchUser`),
+			},
+		},
+	})
+}
+
+func TestAccRgwUserRateLimitResource_timeouts(t *testing.T) {
+	server := acctest.NewServer(t)
+	server.AddUser("reporter", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwUserRateLimitTimeoutsConfig("1m"),
+				Check:  resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "timeouts.update", "1m"),
+			},
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwUserRateLimitTimeoutsConfig("2m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "timeouts.update", "2m"),
+					resource.TestCheckResourceAttr("ceph_rgw_user_ratelimit.test", "max_read_ops", "100"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwBucketRateLimitResource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_bucket" "test" {
+  name = "limited"
+}
+
+resource "ceph_rgw_bucket_ratelimit" "test" {
+  bucket = ceph_rgw_bucket.test.name
+
+  max_read_ops  = 50
+  max_write_ops = 5
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_bucket_ratelimit.test", "bucket", "limited"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket_ratelimit.test", "max_read_ops", "50"),
+					resource.TestCheckResourceAttr("ceph_rgw_bucket_ratelimit.test", "max_write_ops", "5"),
+				),
+			},
+			{
+				ResourceName:                         "ceph_rgw_bucket_ratelimit.test",
+				ImportState:                          true,
+				ImportStateId:                        "limited",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "bucket",
+			},
+		},
+	})
+}
+
+func TestAccRgwGlobalRateLimitResource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwRateLimitConfig("ceph_rgw_global_ratelimit", "scope", "anon", 20, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_global_ratelimit.test", "scope", "anon"),
+					resource.TestCheckResourceAttr("ceph_rgw_global_ratelimit.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("ceph_rgw_global_ratelimit.test", "max_read_ops", "20"),
+				),
+			},
+			{
+				ResourceName:                         "ceph_rgw_global_ratelimit.test",
+				ImportState:                          true,
+				ImportStateId:                        "anon",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "scope",
+			},
+		},
+	})
+}
+
+func TestAccRgwGlobalRateLimitResource_invalidScope(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.ProviderConfig(server) + testAccRgwRateLimitConfig("ceph_rgw_global_ratelimit", "scope", "tenant", 20, 0),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+		},
+	})
+}
+
+func testAccRgwRateLimitConfig(resourceType string, key string, value string, maxReadOps int, maxWriteOps int) string {
+	return fmt.Sprintf(`
+resource %q "test" {
+  %s = %q
+
+  max_read_ops  = %d
+  max_write_ops = %d
+}
+`, resourceType, key, value, maxReadOps, maxWriteOps)
+}
+
+func testAccRgwUserRateLimitTimeoutsConfig(update string) string {
+	return fmt.Sprintf(`
+resource "ceph_rgw_user_ratelimit" "test" {
+  user_id      = "reporter"
+  max_read_ops = 100
+
+  timeouts {
+    update = %q
+  }
+}
+`, update)
+}
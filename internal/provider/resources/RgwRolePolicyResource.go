@@ -0,0 +1,227 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwRolePolicyResource{}
+	_ resource.ResourceWithConfigure   = &RgwRolePolicyResource{}
+	_ resource.ResourceWithImportState = &RgwRolePolicyResource{}
+)
+
+const (
+	defaultRgwRolePolicyCreateTimeout = 5 * time.Minute
+	defaultRgwRolePolicyReadTimeout   = 5 * time.Minute
+	defaultRgwRolePolicyUpdateTimeout = 5 * time.Minute
+	defaultRgwRolePolicyDeleteTimeout = 5 * time.Minute
+)
+
+type RgwRolePolicyResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwRolePolicyResource() resource.Resource {
+	return &RgwRolePolicyResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwRolePolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_role_policy"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwRolePolicyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwRolePolicyResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwRolePolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwRolePolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwRolePolicy
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwRolePolicyCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.put(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("PutRolePolicy failed", err.Error())
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwRolePolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwRolePolicy
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwRolePolicyReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.GetRolePolicyWithContext(ctx, &iam.GetRolePolicyInput{
+		RoleName:   data.Role.ValueStringPointer(),
+		PolicyName: data.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "Role policy "+data.Role.ValueString()+"/"+data.Name.ValueString()+" not found, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetRolePolicy failed", err.Error())
+		return
+	}
+
+	data = model.ToRgwRolePolicy(output, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwRolePolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data model.RgwRolePolicy
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultRgwRolePolicyUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.put(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("PutRolePolicy failed", err.Error())
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwRolePolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwRolePolicy
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwRolePolicyDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.DeleteRolePolicyWithContext(ctx, &iam.DeleteRolePolicyInput{
+		RoleName:   data.Role.ValueStringPointer(),
+		PolicyName: data.Name.ValueStringPointer(),
+	})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("DeleteRolePolicy failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwRolePolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	role, name, found := strings.Cut(req.ID, "/")
+	if !found || role == "" || name == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected an import ID of the form <role>/<name>, got: "+req.ID)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), role)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func (r *RgwRolePolicyResource) put(ctx context.Context, data model.RgwRolePolicy) error {
+	_, err := r.clientLibs.IAM.PutRolePolicyWithContext(ctx, &iam.PutRolePolicyInput{
+		RoleName:       data.Role.ValueStringPointer(),
+		PolicyName:     data.Name.ValueStringPointer(),
+		PolicyDocument: data.Policy.ValueStringPointer(),
+	})
+	return err
+}
@@ -0,0 +1,246 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lib "terraform-provider-ceph/internal/provider/lib"
+	model "terraform-provider-ceph/internal/provider/models"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RgwRoleResource{}
+	_ resource.ResourceWithConfigure   = &RgwRoleResource{}
+	_ resource.ResourceWithImportState = &RgwRoleResource{}
+)
+
+const (
+	defaultRgwRoleCreateTimeout = 5 * time.Minute
+	defaultRgwRoleReadTimeout   = 5 * time.Minute
+	defaultRgwRoleUpdateTimeout = 5 * time.Minute
+	defaultRgwRoleDeleteTimeout = 5 * time.Minute
+)
+
+type RgwRoleResource struct {
+	clientLibs *lib.CephProviderClientLibs
+}
+
+func NewRgwRoleResource() resource.Resource {
+	return &RgwRoleResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *RgwRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rgw_role"
+}
+
+// Schema defines the schema for the resource.
+func (r *RgwRoleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwRoleResourceSchema(ctx)
+}
+
+// Configure implements resource.ResourceWithConfigure.
+func (r *RgwRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientLibs, ok := req.ProviderData.(*lib.CephProviderClientLibs)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CephProviderClientLibs, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.clientLibs = clientLibs
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *RgwRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data model.RgwRole
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwRoleCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.CreateRoleWithContext(ctx, &iam.CreateRoleInput{
+		RoleName:                 data.Name.ValueStringPointer(),
+		Path:                     data.Path.ValueStringPointer(),
+		AssumeRolePolicyDocument: data.AssumeRolePolicy.ValueStringPointer(),
+		MaxSessionDuration:       data.MaxSessionDuration.ValueInt64Pointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("CreateRole failed", err.Error())
+		return
+	}
+
+	data = model.ToRgwRole(output.Role, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RgwRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data model.RgwRole
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwRoleReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	output, err := r.clientLibs.IAM.GetRoleWithContext(ctx, &iam.GetRoleInput{
+		RoleName: data.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		if lib.IsNotFound(err) {
+			tflog.Debug(ctx, "Role "+data.Name.ValueString()+" not found, removing from state")
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("GetRole failed", err.Error())
+		return
+	}
+
+	data = model.ToRgwRole(output.Role, data)
+
+	// Set state
+	diags = resp.State.Set(ctx, &data)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *RgwRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state model.RgwRole
+	var desired model.RgwRole
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &desired)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := desired.Timeouts.Update(ctx, defaultRgwRoleUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if !desired.AssumeRolePolicy.Equal(state.AssumeRolePolicy) {
+		_, err := r.clientLibs.IAM.UpdateAssumeRolePolicyWithContext(ctx, &iam.UpdateAssumeRolePolicyInput{
+			RoleName:       desired.Name.ValueStringPointer(),
+			PolicyDocument: desired.AssumeRolePolicy.ValueStringPointer(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("UpdateAssumeRolePolicy failed", err.Error())
+			return
+		}
+	}
+
+	if !desired.MaxSessionDuration.Equal(state.MaxSessionDuration) {
+		_, err := r.clientLibs.IAM.UpdateRoleWithContext(ctx, &iam.UpdateRoleInput{
+			RoleName:           desired.Name.ValueStringPointer(),
+			MaxSessionDuration: desired.MaxSessionDuration.ValueInt64Pointer(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("UpdateRole failed", err.Error())
+			return
+		}
+	}
+
+	output, err := r.clientLibs.IAM.GetRoleWithContext(ctx, &iam.GetRoleInput{
+		RoleName: desired.Name.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("GetRole failed", err.Error())
+		return
+	}
+
+	desired = model.ToRgwRole(output.Role, desired)
+
+	// Set state
+	diags = resp.State.Set(ctx, &desired)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RgwRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data model.RgwRole
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwRoleDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.clientLibs.IAM.DeleteRoleWithContext(ctx, &iam.DeleteRoleInput{
+		RoleName: data.Name.ValueStringPointer(),
+	})
+	if err != nil && !lib.IsNotFound(err) {
+		resp.Diagnostics.AddError("DeleteRole failed", err.Error())
+		return
+	}
+}
+
+func (r *RgwRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
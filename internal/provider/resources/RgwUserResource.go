@@ -3,7 +3,7 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	lib "terraform-provider-ceph/internal/provider/lib"
 	model "terraform-provider-ceph/internal/provider/models"
@@ -21,6 +21,13 @@ var (
 	_ resource.ResourceWithImportState = &RgwUserResource{}
 )
 
+const (
+	defaultRgwUserCreateTimeout = 5 * time.Minute
+	defaultRgwUserReadTimeout   = 5 * time.Minute
+	defaultRgwUserUpdateTimeout = 5 * time.Minute
+	defaultRgwUserDeleteTimeout = 5 * time.Minute
+)
+
 type RgwUserResource struct {
 	clientLibs *lib.CephProviderClientLibs
 }
@@ -35,8 +42,8 @@ func (r *RgwUserResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *RgwUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = model.GetRgwUserResourceSchema()
+func (r *RgwUserResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = model.GetRgwUserResourceSchema(ctx)
 }
 
 // Configure implements resource.ResourceWithConfigure.
@@ -67,6 +74,15 @@ func (r *RgwUserResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultRgwUserCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var displayName = data.Name.ValueString()
 	if displayName == "" {
 		displayName = data.Id.ValueString()
@@ -95,10 +111,31 @@ func (r *RgwUserResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	data = model.ToRgwUser(created)
+	if data.AccountId.ValueString() != "" {
+		err = r.clientLibs.Admin.SetUserAccount(ctx, created.ID, data.AccountId.ValueString(), data.AccountRoot.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("SetUserAccount failed", err.Error())
+
+			// Remove the user rather than leave one behind that is not in the state
+			if err := r.clientLibs.Rgw.RemoveUser(ctx, admin.User{ID: created.ID}); err != nil {
+				resp.Diagnostics.AddError("RemoveUser failed", "User "+created.ID+" was created but could not be removed after SetUserAccount failed: "+err.Error())
+			}
+			return
+		}
+	}
+
+	account, err := r.clientLibs.Admin.GetUserAccount(ctx, created.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("GetUserAccount failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwUser(created, account)
+	data.Timeouts = timeouts
 
 	// Set state
-	diags := resp.State.Set(ctx, &data)
+	diags = resp.State.Set(ctx, &data)
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -117,13 +154,22 @@ func (r *RgwUserResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultRgwUserReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	var uid = data.Id.ValueString()
 
 	user, err := r.clientLibs.Rgw.GetUser(ctx, admin.User{ID: uid})
 
 	if err != nil {
-		// Check if the error is a "NoSuchUser" error
-		if strings.HasPrefix(err.Error(), "NoSuchUser") {
+		// Check if the user is gone
+		if lib.IsNotFound(err) {
 			tflog.Debug(ctx, "User "+uid+" not found, removing from state")
 			resp.State.RemoveResource(ctx)
 			return
@@ -132,10 +178,18 @@ func (r *RgwUserResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	data = model.ToRgwUser(user)
+	account, err := r.clientLibs.Admin.GetUserAccount(ctx, uid)
+	if err != nil {
+		resp.Diagnostics.AddError("GetUserAccount failed", err.Error())
+		return
+	}
+
+	timeouts := data.Timeouts
+	data = model.ToRgwUser(user, account)
+	data.Timeouts = timeouts
 
 	// Set state
-	diags := resp.State.Set(ctx, &data)
+	diags = resp.State.Set(ctx, &data)
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -158,6 +212,15 @@ func (r *RgwUserResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := desired.Timeouts.Update(ctx, defaultRgwUserUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	if state.Id != desired.Id {
 		resp.Diagnostics.AddError("Update not supported", "ID cannot be changed")
 		return
@@ -205,10 +268,25 @@ func (r *RgwUserResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	state = model.ToRgwUser(modified)
+	if desired.AccountId.ValueString() != "" && (!desired.AccountId.Equal(state.AccountId) || !desired.AccountRoot.Equal(state.AccountRoot)) {
+		err = r.clientLibs.Admin.SetUserAccount(ctx, modified.ID, desired.AccountId.ValueString(), desired.AccountRoot.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("SetUserAccount failed", err.Error())
+			return
+		}
+	}
+
+	account, err := r.clientLibs.Admin.GetUserAccount(ctx, modified.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("GetUserAccount failed", err.Error())
+		return
+	}
+
+	state = model.ToRgwUser(modified, account)
+	state.Timeouts = desired.Timeouts
 
 	// Set state (for now, set it to the state)
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -227,6 +305,15 @@ func (r *RgwUserResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultRgwUserDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.clientLibs.Rgw.RemoveUser(ctx, admin.User{ID: data.Id.ValueString()})
 	if err != nil {
 		resp.Diagnostics.AddError("DeleteUser failed", err.Error())
@@ -0,0 +1,175 @@
+package resources_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-ceph/internal/provider/acctest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccRgwUserResource(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwUserConfig("alice", "Alice", 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "id", "alice"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "name", "Alice"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "max_buckets", "10"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "account_root", "false"),
+					resource.TestCheckResourceAttrSet("ceph_rgw_user.test", "access_key"),
+					resource.TestCheckResourceAttrSet("ceph_rgw_user.test", "secret_key"),
+				),
+			},
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwUserConfig("alice", "Alice Liddell", 20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "name", "Alice Liddell"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "max_buckets", "20"),
+				),
+			},
+			{
+				ResourceName:            "ceph_rgw_user.test",
+				ImportState:             true,
+				ImportStateId:           "alice",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccRgwUserResource_keys(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_user" "test" {
+  id         = "bob"
+  name       = "Bob"
+  access_key = "BOBACCESSKEY"
+  secret_key = "bobsecretkey"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "access_key", "BOBACCESSKEY"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "secret_key", "bobsecretkey"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwUserResource_existingUser(t *testing.T) {
+	server := acctest.NewServer(t)
+	server.AddUser("carol", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.ProviderConfig(server) + testAccRgwUserConfig("carol", "Carol", 10),
+				ExpectError: regexp.MustCompile(`UserAlreadyExists`),
+			},
+		},
+	})
+}
+
+func TestAccRgwUserResource_account(t *testing.T) {
+	server := acctest.NewServer(t)
+	server.AddAccount("RGW11111111111111111")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + testAccRgwUserAccountConfig("erin", "RGW11111111111111111"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "account_id", "RGW11111111111111111"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "account_root", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRgwUserResource_unknownAccount(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      acctest.ProviderConfig(server) + testAccRgwUserAccountConfig("frank", "RGW22222222222222222"),
+				ExpectError: regexp.MustCompile(`SetUserAccount failed`),
+			},
+			{
+				Config: acctest.ProviderConfig(server),
+				Check: func(_ *terraform.State) error {
+					// A user that could not join its account must not be left behind
+					if server.HasUser("frank") {
+						return fmt.Errorf("user frank still exists")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccRgwUserResource_timeouts(t *testing.T) {
+	server := acctest.NewServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+resource "ceph_rgw_user" "test" {
+  id   = "dave"
+  name = "Dave"
+
+  timeouts {
+    create = "1m"
+    read   = "30s"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "timeouts.create", "1m"),
+					resource.TestCheckResourceAttr("ceph_rgw_user.test", "timeouts.read", "30s"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRgwUserConfig(id string, name string, maxBuckets int) string {
+	return fmt.Sprintf(`
+resource "ceph_rgw_user" "test" {
+  id          = %q
+  name        = %q
+  max_buckets = %d
+}
+`, id, name, maxBuckets)
+}
+
+func testAccRgwUserAccountConfig(id string, accountId string) string {
+	return fmt.Sprintf(`
+resource "ceph_rgw_user" "test" {
+  id           = %q
+  account_id   = %q
+  account_root = true
+}
+`, id, accountId)
+}